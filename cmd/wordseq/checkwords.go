@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CheckWordsCmd reports the words of a word list that are missing from a dictionary and
+// writes the ones that are present to a new file.
+type CheckWordsCmd struct {
+	Dictionary string `kong:"name='dictionary',help='Dictionary text file, one word per line. Defaults to the embedded Hunspell dictionary.'"`
+	Wordlist   string `kong:"name='wordlist',help='Word list to check against the dictionary, one word per line. Defaults to the simple word list of the language pack.'"`
+	Output     string `kong:"name='output',short='o',default='valid_words_output.txt',help='File to write the words found in the dictionary to.'"`
+}
+
+// Run scans cmd.Wordlist, or the embedded simple word list, against cmd.Dictionary, or the
+// embedded dictionary if none is given.
+func (cmd *CheckWordsCmd) Run(dicts *Dictionaries) error {
+	dictionaryName := cmd.Dictionary
+	if dictionaryName == "" {
+		dictionaryName = "the embedded Hunspell dictionary"
+	}
+	wordlistName := cmd.Wordlist
+	if wordlistName == "" {
+		wordlistName = fmt.Sprintf("the embedded %s usable.txt", dicts.lang.Code)
+	}
+	fmt.Printf("Attempting to load dictionary words from: %s\n", dictionaryName)
+	fmt.Printf("Words to check will be read from: %s\n", wordlistName)
+	fmt.Printf("Valid words found will be written to: %s\n\n", cmd.Output)
+
+	// 1. Load words from the dictionary into a set for efficient lookup.
+	dictionarySet := make(map[string]struct{})
+
+	if cmd.Dictionary == "" {
+		for _, word := range dicts.WordList() {
+			dictionarySet[word] = struct{}{}
+		}
+	} else if err := loadWordSet(cmd.Dictionary, dictionarySet); err != nil {
+		return err
+	}
+	fmt.Printf("Successfully loaded %d unique words into the dictionary set from %s.\n\n", len(dictionarySet), dictionaryName)
+
+	// 2. Create/Open the output file for writing valid words.
+	outputFile, err := os.Create(cmd.Output)
+	if err != nil {
+		return fmt.Errorf("creating output file '%s': %w", cmd.Output, err)
+	}
+	defer outputFile.Close()
+
+	outputWriter := bufio.NewWriter(outputFile)
+
+	// 3. Open and scan the word list.
+	fmt.Printf("Scanning wordlist '%s', reporting missing words, and writing valid words to '%s':\n", wordlistName, cmd.Output)
+	var wordlist io.Reader = strings.NewReader(dicts.lang.simple)
+	if cmd.Wordlist != "" {
+		checkFile, err := os.Open(cmd.Wordlist)
+		if err != nil {
+			return fmt.Errorf("opening wordlist file '%s': %w", cmd.Wordlist, err)
+		}
+		defer checkFile.Close()
+		wordlist = checkFile
+	}
+
+	checkScanner := bufio.NewScanner(wordlist)
+	missingWordsCount := 0
+	validWordsWrittenCount := 0
+	wordsScannedCount := 0
+
+	for checkScanner.Scan() {
+		wordsScannedCount++
+		wordToCheck := strings.TrimSpace(checkScanner.Text())
+		if wordToCheck == "" {
+			// Skip empty lines in the wordlist
+			continue
+		}
+
+		// This check is case-sensitive.
+		if _, found := dictionarySet[wordToCheck]; found {
+			if _, err := outputWriter.WriteString(wordToCheck + "\n"); err != nil {
+				// Report error writing to output file but continue processing other words.
+				fmt.Fprintf(os.Stderr, "Error writing word '%s' to output file '%s': %v\n", wordToCheck, cmd.Output, err)
+			} else {
+				validWordsWrittenCount++
+			}
+		} else {
+			fmt.Printf("Missing: '%s'\n", wordToCheck)
+			missingWordsCount++
+		}
+	}
+	if err := checkScanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "\nError reading from wordlist '%s': %v\n", wordlistName, err)
+	}
+	if err := outputWriter.Flush(); err != nil {
+		return fmt.Errorf("flushing output writer for '%s': %w", cmd.Output, err)
+	}
+
+	fmt.Printf("\n--- Scan Complete ---\n")
+	fmt.Printf("Total words scanned from '%s': %d\n", wordlistName, wordsScannedCount)
+	fmt.Printf("Total words reported as missing (not in %s): %d\n", dictionaryName, missingWordsCount)
+	fmt.Printf("Total valid words written to '%s': %d\n", cmd.Output, validWordsWrittenCount)
+	return nil
+}
+
+// loadWordSet adds the words of a text file, one per line, to set.
+func loadWordSet(path string, set map[string]struct{}) error {
+	dictFile, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening dictionary words file '%s': %w", path, err)
+	}
+	defer dictFile.Close()
+
+	dictScanner := bufio.NewScanner(dictFile)
+	for dictScanner.Scan() {
+		word := strings.TrimSpace(dictScanner.Text())
+		if word != "" {
+			set[word] = struct{}{}
+		}
+	}
+	if err := dictScanner.Err(); err != nil {
+		return fmt.Errorf("reading from dictionary words file '%s': %w", path, err)
+	}
+	return nil
+}
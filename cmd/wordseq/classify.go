@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// classifyBatchSize is the number of candidate grids a worker explores per batch. Batches are
+// handed out in order and each candidate is drawn from its own seed, so the grids each profile
+// receives do not depend on the number of workers, and the seed of every grid is recorded in
+// its provenance.
+const classifyBatchSize = 16
+
+// classifyGroup is a set of profiles whose levels have the same grid size and rules, so that
+// any grid explored for one of them can be judged for the others as well.
+type classifyGroup struct {
+	name string
+	cmds []*GenerateCmd
+}
+
+// classifyGroups groups the profiles by grid size and rules, keeping the order of the config.
+func (cmd *GenerateCmd) classifyGroups(profiles []Profile) []*classifyGroup {
+	var groups []*classifyGroup
+	byShape := make(map[string]*classifyGroup)
+	for _, profile := range profiles {
+		p := cmd.applyProfile(profile)
+		shape := fmt.Sprintf("%dx%d/%d/%d", p.GridRows, p.GridCols, p.WordLength, p.RequiredMaxTurns)
+		group, ok := byShape[shape]
+		if !ok {
+			group = &classifyGroup{}
+			byShape[shape] = group
+			groups = append(groups, group)
+		}
+		group.cmds = append(group.cmds, p)
+	}
+	for _, group := range groups {
+		names := make([]string, len(group.cmds))
+		for i, p := range group.cmds {
+			names[i] = p.Difficulty
+		}
+		group.name = strings.Join(names, "+")
+	}
+	return groups
+}
+
+// generateClassified fills the calendars of several profiles in one pass per group of profiles
+// with the same grid size and rules. Every grid that passes the checks shared by the group is
+// scored once and given to the first profile, in config order, that still has dates to fill
+// and whose turn, word, filter and score requirements it meets. Profiles are expected to use
+// score ranges that do not overlap much; a grid is never used twice, nor is a grid already
+// published in one of the group's calendars.
+func (cmd *GenerateCmd) generateClassified(ctx context.Context, dicts *Dictionaries, profiles []Profile) error {
+	for _, group := range cmd.classifyGroups(profiles) {
+		fmt.Printf("\n=== Profiles %s ===\n", strings.ReplaceAll(group.name, "+", ", "))
+		if err := cmd.fillGroup(ctx, dicts, group); err != nil {
+			return fmt.Errorf("profiles %s: %w", group.name, err)
+		}
+	}
+	return nil
+}
+
+// classifyBatch is the outcome of exploring the grids of one batch seed.
+type classifyBatch struct {
+	index      int
+	candidates []WorkerResult
+}
+
+// fillGroup generates the missing levels of every profile of group from one stream of grids.
+func (cmd *GenerateCmd) fillGroup(ctx context.Context, dicts *Dictionaries, group *classifyGroup) error {
+	pending := make([][]int, len(group.cmds))
+	remaining := 0
+	for i, p := range group.cmds {
+		if err := p.checkStrategies(p.players); err != nil {
+			return fmt.Errorf("%s: %w", p.Difficulty, err)
+		}
+		for gridIndex := range p.jobs() {
+			pending[i] = append(pending[i], gridIndex)
+		}
+		remaining += len(pending[i])
+		fmt.Printf("%s: %d of %d levels from %s need generating under %s.\n", p.Difficulty, len(pending[i]), p.NumGrids, p.StartDate, p.Output)
+	}
+	if remaining == 0 {
+		fmt.Println("Nothing to generate.")
+		return nil
+	}
+	published, err := group.publishedGrids()
+	if err != nil {
+		return err
+	}
+
+	first := group.cmds[0]
+	fmt.Printf("Grid Dimensions: %d rows, %d columns\n", first.GridRows, first.GridCols)
+	fmt.Printf("Word Length: %d\n", first.WordLength)
+	fmt.Printf("Maximum exploration depth: %d\n", first.RequiredMaxTurns)
+	fmt.Printf("Seed: %d (group %q)\n", cmd.Seed, group.name)
+
+	wordMap := dicts.Words()
+	simpleWordMap := dicts.Simple()
+	rules := first.Rules()
+
+	startTime := time.Now()
+	numWorkers := runtime.NumCPU()
+	fmt.Printf("Using %d worker goroutines.\n", numWorkers)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batchesChan := make(chan int)
+	resultsChan := make(chan classifyBatch, numWorkers)
+	var wg sync.WaitGroup
+	var gridAttemptsTotal int64
+	var gridAbortsTotal int64
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range batchesChan {
+				batch := classifyBatch{index: index}
+				for k := range classifyBatchSize {
+					gridSeed := deriveCandidateSeed(cmd.Seed, group.name, index*classifyBatchSize+k)
+					rng := rand.New(rand.NewPCG(gridSeed, 0))
+					grid := generateGrid(rng, dicts.Letters(), first.GridRows, first.GridCols)
+					if grid == nil {
+						continue
+					}
+					atomic.AddInt64(&gridAttemptsTotal, 1)
+					result, ok, err := first.evaluate(ctx, rules, grid, wordMap, simpleWordMap)
+					if errors.Is(err, engine.ErrBudgetExceeded) {
+						atomic.AddInt64(&gridAbortsTotal, 1)
+						continue
+					} else if err != nil {
+						return
+					}
+					if ok {
+						result.GridSeed = gridSeed
+						batch.candidates = append(batch.candidates, result)
+					}
+				}
+				select {
+				case resultsChan <- batch:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(batchesChan)
+		for index := 0; ; index++ {
+			select {
+			case batchesChan <- index:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	// Batches finish out of order. They are held back until every earlier batch is in, so that
+	// grids are always offered to the profiles in the same order.
+	held := make(map[int]classifyBatch)
+	next := 0
+	written := make([]int, len(group.cmds))
+	var writeErr error
+	interrupted := ctx.Done()
+resultsLoop:
+	for {
+		select {
+		case batch, ok := <-resultsChan:
+			if !ok {
+				break resultsLoop
+			}
+			held[batch.index] = batch
+			for remaining > 0 && writeErr == nil {
+				ready, ok := held[next]
+				if !ok {
+					break
+				}
+				delete(held, next)
+				next++
+				for _, result := range ready.candidates {
+					key := engine.GridToString(result.Grid)
+					if _, ok := published[key]; ok {
+						continue
+					}
+					i := group.assign(pending, result)
+					if i < 0 {
+						continue
+					}
+					published[key] = struct{}{}
+					result.GridIndex = pending[i][0]
+					pending[i] = pending[i][1:]
+					remaining--
+					if err := group.cmds[i].WriteOutput(result); err != nil {
+						writeErr = fmt.Errorf("writing grid index %d of %s: %w", result.GridIndex, group.cmds[i].Difficulty, err)
+						break
+					}
+					written[i]++
+					if remaining == 0 {
+						break
+					}
+				}
+			}
+			if remaining == 0 || writeErr != nil {
+				cancel()
+			}
+
+		case <-interrupted:
+			fmt.Println("Interrupted. Waiting for workers to stop...")
+			interrupted = nil
+
+		case <-ticker.C:
+			fmt.Printf("...elapsed: %v, checked ~%d grids (%d levels left, abandoned %d over budget)\n",
+				time.Since(startTime).Round(time.Second), atomic.LoadInt64(&gridAttemptsTotal), remaining, atomic.LoadInt64(&gridAbortsTotal))
+		}
+	}
+
+	fmt.Printf("\nSearch finished after %v (~%d attempts).\n", time.Since(startTime).Round(time.Second), atomic.LoadInt64(&gridAttemptsTotal))
+	for i, p := range group.cmds {
+		fmt.Printf("  %-22s  %d written, %d left\n", p.Difficulty, written[i], len(pending[i]))
+	}
+	if aborts := atomic.LoadInt64(&gridAbortsTotal); aborts > 0 {
+		fmt.Printf("Abandoned %d grids that exceeded the exploration budget.\n", aborts)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	if remaining > 0 {
+		fmt.Println("Run stopped early. Rerun the same command to resume.")
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return errors.New("workers stopped before every level was generated")
+	}
+	return nil
+}
+
+// assign returns the index of the first profile with dates left to fill that accepts result,
+// or -1 if none does.
+func (group *classifyGroup) assign(pending [][]int, result WorkerResult) int {
+	for i, p := range group.cmds {
+		if len(pending[i]) > 0 && p.accepts(result) {
+			return i
+		}
+	}
+	return -1
+}
+
+// publishedGrids returns the initial grids of the levels already written for the profiles of
+// the group, keyed by engine.GridToString. A rerun that fills a gap draws the same candidates
+// as the run before it, so without them it would publish a grid that is already in use.
+func (group *classifyGroup) publishedGrids() (map[string]struct{}, error) {
+	published := make(map[string]struct{})
+	for _, p := range group.cmds {
+		if _, err := os.Stat(p.Output); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		err := walkLevels(p.Output, func(path string, level *engine.FullExplorationOutput) error {
+			grid, err := engine.ConvertJsonGridToGrid(level.InitialGrid)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			published[engine.GridToString(grid)] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading the levels of %s: %w", p.Difficulty, err)
+		}
+	}
+	return published, nil
+}
+
+// deriveCandidateSeed derives the seed of one candidate grid from the base seed and the profiles
+// of the group, like deriveSeed does for a single date. The grid is the first one drawn from a
+// source with that seed.
+func deriveCandidateSeed(seed uint64, group string, index int) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/candidate/%d", seed, group, index)
+	return h.Sum64()
+}
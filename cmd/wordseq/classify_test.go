@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+func TestClassifyAssign(t *testing.T) {
+	dicts, err := newDictionaries(DictionaryFlags{Lang: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := func(name string, minScore, maxScore float64) *GenerateCmd {
+		return &GenerateCmd{
+			Difficulty:       name,
+			RequiredMinTurns: 3,
+			MaxUniqueWords:   10,
+			FilterFlags:      FilterFlags{MinScore: minScore, MaxScore: maxScore},
+			dicts:            dicts,
+		}
+	}
+	group := &classifyGroup{cmds: []*GenerateCmd{
+		profile("normal", 0, 0.3),
+		profile("hard", 0.3, 0.6),
+		profile("impossible", 0.6, 0),
+	}}
+	result := func(score float64, depth int) WorkerResult {
+		return WorkerResult{
+			MaxDepth:       depth,
+			Metrics:        engine.Metrics{UniqueWords: 5},
+			Classification: engine.Classification{Score: score},
+		}
+	}
+	pending := [][]int{{0}, {0}, {0}}
+
+	tests := []struct {
+		name    string
+		result  WorkerResult
+		pending [][]int
+		want    int
+	}{
+		{"easy", result(0.1, 4), pending, 0},
+		{"on the boundary goes to the first profile", result(0.3, 4), pending, 0},
+		{"medium", result(0.45, 4), pending, 1},
+		{"hard", result(0.9, 4), pending, 2},
+		{"too shallow", result(0.45, 2), pending, -1},
+		{"calendar full", result(0.45, 4), [][]int{{0}, {}, {0}}, -1},
+		{"falls through to a later profile", result(0.3, 4), [][]int{{}, {0}, {0}}, 1},
+	}
+	for _, tt := range tests {
+		if got := group.assign(tt.pending, tt.result); got != tt.want {
+			t.Errorf("%s: assign = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
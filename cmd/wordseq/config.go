@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/sudorandom/wordchain/engine"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateConfig describes a multi-difficulty generation run.
+type GenerateConfig struct {
+	Profiles []Profile `yaml:"profiles"`
+	// Weights of the difficulty score. Unset, engine.DefaultDifficultyWeights are used.
+	Weights *engine.DifficultyWeights `yaml:"weights"`
+}
+
+// Profile holds the generation settings of one named difficulty.
+type Profile struct {
+	Name             string `yaml:"name"`
+	GridRows         int    `yaml:"gridRows"`
+	GridCols         int    `yaml:"gridCols"`
+	WordLength       int    `yaml:"wordLength"`
+	RequiredMinTurns int    `yaml:"minTurns"`
+	RequiredMaxTurns int    `yaml:"maxTurns"`
+	MaxUniqueWords   int    `yaml:"maxUniqueWords"`
+	Output           string `yaml:"output"`
+	// Search overrides --search for this profile.
+	Search string `yaml:"search"`
+
+	Filters FilterFlags `yaml:",inline"`
+}
+
+// loadGenerateConfig reads a generation config file. If names is not empty, only the profiles
+// with those names are kept, in the order they appear in the file.
+func loadGenerateConfig(path string, names []string) (*GenerateConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config GenerateConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing '%s': %w", path, err)
+	}
+
+	seen := make(map[string]struct{}, len(config.Profiles))
+	for i, profile := range config.Profiles {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("%s: profile %d has no name", path, i)
+		}
+		if _, ok := seen[profile.Name]; ok {
+			return nil, fmt.Errorf("%s: duplicate profile %q", path, profile.Name)
+		}
+		seen[profile.Name] = struct{}{}
+		if profile.GridRows <= 0 || profile.GridCols <= 0 || profile.WordLength <= 0 {
+			return nil, fmt.Errorf("%s: profile %q needs positive gridRows, gridCols and wordLength", path, profile.Name)
+		}
+		if profile.RequiredMinTurns > profile.RequiredMaxTurns {
+			return nil, fmt.Errorf("%s: profile %q has minTurns above maxTurns", path, profile.Name)
+		}
+		if profile.Output == "" {
+			return nil, fmt.Errorf("%s: profile %q has no output directory", path, profile.Name)
+		}
+		switch profile.Search {
+		case "", searchRandom, searchClimb, searchAnneal, searchConstruct:
+		default:
+			return nil, fmt.Errorf("%s: profile %q has unknown search %q", path, profile.Name, profile.Search)
+		}
+	}
+	for _, name := range names {
+		if _, ok := seen[name]; !ok {
+			return nil, fmt.Errorf("%s: no profile named %q", path, name)
+		}
+	}
+	if len(names) > 0 {
+		config.Profiles = slices.DeleteFunc(config.Profiles, func(p Profile) bool {
+			return !slices.Contains(names, p.Name)
+		})
+	}
+	return &config, nil
+}
+
+// applyProfile returns a copy of cmd with the settings of the profile.
+func (cmd GenerateCmd) applyProfile(profile Profile) *GenerateCmd {
+	cmd.GridRows = profile.GridRows
+	cmd.GridCols = profile.GridCols
+	cmd.WordLength = profile.WordLength
+	cmd.RequiredMinTurns = profile.RequiredMinTurns
+	cmd.RequiredMaxTurns = profile.RequiredMaxTurns
+	cmd.MaxUniqueWords = profile.MaxUniqueWords
+	cmd.Output = cmd.dicts.Language().levelDir(profile.Output)
+	cmd.FilterFlags = cmd.FilterFlags.override(profile.Filters)
+	if profile.Search != "" {
+		cmd.Search = profile.Search
+	}
+	cmd.Difficulty = profile.Name
+	return &cmd
+}
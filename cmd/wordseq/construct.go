@@ -0,0 +1,476 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// searchConstruct builds grids backwards from a chain of words, as chosen by --search.
+const searchConstruct = "construct"
+
+// chainRepairs is the number of times the free cells that spell words outside a planted chain
+// are redrawn before the chain is given up.
+const chainRepairs = 64
+
+// chainPicks is the number of words tried in a span before moving on to the next span.
+const chainPicks = 8
+
+// fillerDraws is the number of letters drawn for a free cell, of which the one that spells the
+// fewest words outside the chain is kept.
+const fillerDraws = 4
+
+// gridSpan is a run of WordLength cells along a row or a column.
+type gridSpan struct {
+	row, col int
+	// dRow and dCol step from one cell of the span to the next.
+	dRow, dCol int
+}
+
+// gridSpans returns every span of length cells in a grid of the given size.
+func gridSpans(rows, cols, length int) []gridSpan {
+	var spans []gridSpan
+	for r := range rows {
+		for c := 0; c+length <= cols; c++ {
+			spans = append(spans, gridSpan{row: r, col: c, dCol: 1})
+		}
+	}
+	for c := range cols {
+		for r := 0; r+length <= rows; r++ {
+			spans = append(spans, gridSpan{row: r, col: c, dRow: 1})
+		}
+	}
+	return spans
+}
+
+// cell returns the coordinates of the i-th cell of the span.
+func (s gridSpan) cell(i int) engine.Coordinates {
+	return engine.Coordinates{Row: s.row + i*s.dRow, Col: s.col + i*s.dCol}
+}
+
+// contains reports whether the span covers the cell at p.
+func (s gridSpan) contains(p engine.Coordinates, length int) bool {
+	for i := range length {
+		if s.cell(i) == p {
+			return true
+		}
+	}
+	return false
+}
+
+// chainBuilder plants a chain of words in a grid from the last word to the first. After a word
+// is planted, a swap of two adjacent cells breaks it again, so that playing the swap forwards
+// forms the word. Cells whose letters a later step of the chain relies on are locked and only
+// ever overwritten with the letter they already hold.
+type chainBuilder struct {
+	rng     *rand.Rand
+	letters *letterDistribution
+	rules   engine.Rules
+	wordMap engine.Dictionary
+	grid    engine.Grid
+	locked  [][]bool
+	length  int
+	spans   []gridSpan
+	used    map[string]struct{}
+	// chain holds the planted words with the swaps that form them, in the order they are played.
+	chain []chainStep
+	// maxWords is the number of unique words, chain included, the grid may end up with.
+	maxWords int
+}
+
+// chainStep is a word of the chain and the swap that forms it.
+type chainStep struct {
+	word string
+	move engine.Move
+}
+
+func newChainBuilder(rng *rand.Rand, letters *letterDistribution, grid engine.Grid, rules engine.Rules, wordMap engine.Dictionary, maxWords int) *chainBuilder {
+	locked := make([][]bool, len(grid))
+	for r := range locked {
+		locked[r] = make([]bool, len(grid[r]))
+	}
+	return &chainBuilder{
+		rng:      rng,
+		letters:  letters,
+		rules:    rules,
+		wordMap:  wordMap,
+		grid:     grid,
+		locked:   locked,
+		length:   rules.WordLength,
+		spans:    gridSpans(len(grid), len(grid[0]), rules.WordLength),
+		used:     make(map[string]struct{}),
+		maxWords: maxWords,
+	}
+}
+
+// step plants one of candidates, which may not repeat a word planted before, and unplays it with
+// a swap. Spans where the locked cells would spell a word outside the chain, which no filler
+// can break, are passed over. It returns false if no candidate fits any span.
+func (b *chainBuilder) step(candidates []string) bool {
+	b.rng.Shuffle(len(b.spans), func(i, j int) { b.spans[i], b.spans[j] = b.spans[j], b.spans[i] })
+	for _, span := range b.spans {
+		for _, word := range b.pick(span, candidates, chainPicks) {
+			if b.plant(span, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// plant plants word in span and unplays it. If no swap breaks the word, the swap also breaks
+// a word planted before, or the locked cells would then spell a word outside the chain, it
+// restores the grid and the locks and returns false.
+func (b *chainBuilder) plant(span gridSpan, word string) bool {
+	grid, locked := engine.CopyGrid(b.grid), make([][]bool, len(b.locked))
+	for r := range locked {
+		locked[r] = slices.Clone(b.locked[r])
+	}
+	for i, letter := range []rune(word) {
+		p := span.cell(i)
+		b.grid[p.Row][p.Col] = letter
+		b.locked[p.Row][p.Col] = true
+	}
+	b.used[word] = struct{}{}
+	if move, ok := b.unplay(span); ok {
+		b.chain = slices.Insert(b.chain, 0, chainStep{word: word, move: move})
+		if b.replays() {
+			if _, _, stuck := b.strays(false); !stuck {
+				return true
+			}
+		}
+		b.chain = b.chain[1:]
+	}
+	delete(b.used, word)
+	for r := range grid {
+		copy(b.grid[r], grid[r])
+		copy(b.locked[r], locked[r])
+	}
+	return false
+}
+
+// pick returns up to n candidates that agree with every locked cell of the span, starting the
+// scan at a random candidate.
+func (b *chainBuilder) pick(span gridSpan, candidates []string, n int) []string {
+	if len(candidates) == 0 {
+		return nil
+	}
+	var picked []string
+	start := b.rng.IntN(len(candidates))
+	for k := range candidates {
+		word := candidates[(start+k)%len(candidates)]
+		if _, ok := b.used[word]; ok {
+			continue
+		}
+		letters := []rune(word)
+		if len(letters) != b.length {
+			continue
+		}
+		fits := true
+		for i, letter := range letters {
+			p := span.cell(i)
+			if b.locked[p.Row][p.Col] && b.grid[p.Row][p.Col] != letter {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			if picked = append(picked, word); len(picked) == n {
+				break
+			}
+		}
+	}
+	return picked
+}
+
+// unplay applies and returns a random swap of two adjacent cells holding different letters, at
+// least one of them in span, which changes the word the span spells. The locks move with the
+// letters. Swaps that bring an unlocked letter into the span are preferred: they leave the next
+// word of the chain a free cell to differ in, the way chains played by hand tend to go.
+func (b *chainBuilder) unplay(span gridSpan) (engine.Move, bool) {
+	var swaps, opening []engine.Move
+	for r := range b.grid {
+		for c := range b.grid[r] {
+			from := engine.Coordinates{Row: r, Col: c}
+			for _, to := range []engine.Coordinates{{Row: r, Col: c + 1}, {Row: r + 1, Col: c}} {
+				if to.Row >= len(b.grid) || to.Col >= len(b.grid[r]) {
+					continue
+				}
+				if b.grid[r][c] == b.grid[to.Row][to.Col] {
+					continue
+				}
+				inFrom, inTo := span.contains(from, b.length), span.contains(to, b.length)
+				if !inFrom && !inTo {
+					continue
+				}
+				swap := engine.Move{Cell1: from, Cell2: to}
+				swaps = append(swaps, swap)
+				if (inFrom && !inTo && !b.locked[to.Row][to.Col]) || (inTo && !inFrom && !b.locked[r][c]) {
+					opening = append(opening, swap)
+				}
+			}
+		}
+	}
+	if len(opening) > 0 {
+		swaps = opening
+	}
+	if len(swaps) == 0 {
+		return engine.Move{}, false
+	}
+	swap := swaps[b.rng.IntN(len(swaps))]
+	c1, c2 := swap.Cell1, swap.Cell2
+	b.grid[c1.Row][c1.Col], b.grid[c2.Row][c2.Col] = b.grid[c2.Row][c2.Col], b.grid[c1.Row][c1.Col]
+	b.locked[c1.Row][c1.Col], b.locked[c2.Row][c2.Col] = b.locked[c2.Row][c2.Col], b.locked[c1.Row][c1.Col]
+	return swap, true
+}
+
+// replays reports whether playing the swaps of the chain forwards forms every word with its
+// own swap. A swap that breaks two words of the chain at once would form both of them, and
+// the chain would end a move short.
+func (b *chainBuilder) replays() bool {
+	grid := b.grid
+	found := make(engine.FoundWordsSet)
+	for i, step := range b.chain {
+		grid = engine.ApplyMove(grid, step.move)
+		words := engine.FindNewWords(b.rules, grid, step.move, b.wordMap, found)
+		if !slices.Contains(words, step.word) {
+			return false
+		}
+		for _, later := range b.chain[i+1:] {
+			if slices.Contains(words, later.word) {
+				return false
+			}
+		}
+		for _, word := range words {
+			found[word] = struct{}{}
+		}
+	}
+	return true
+}
+
+// strays returns the words outside the chain that can be found in the grid: the words it
+// spells before any move, chain words included, and those a sequence of moves forms. Unless
+// follow is set, play is not followed past a stray. The search gives up once the chain and its
+// strays exceed maxWords, since the grid has to change anyway. cells holds the free cells of
+// the starting grid that spell the strays, and stuck reports whether the locked cells alone
+// spell any of them.
+func (b *chainBuilder) strays(follow bool) (words engine.FoundWordsSet, cells map[engine.Coordinates]struct{}, stuck bool) {
+	words = make(engine.FoundWordsSet)
+	cells = make(map[engine.Coordinates]struct{})
+	// mark records the strays among found, given the grid that spells them and the cell of the
+	// starting grid that each of its letters came from, and reports whether there were any.
+	mark := func(grid engine.Grid, origin [][]engine.Coordinates, found []string, initial bool) bool {
+		stray := make(map[string]struct{})
+		for _, word := range found {
+			if _, ok := b.used[word]; initial || !ok {
+				stray[word] = struct{}{}
+				words[word] = struct{}{}
+			}
+		}
+		for _, span := range b.spans {
+			if _, ok := stray[spell(grid, span, b.length)]; !ok {
+				continue
+			}
+			free := false
+			for i := range b.length {
+				p := span.cell(i)
+				if o := origin[p.Row][p.Col]; !b.locked[o.Row][o.Col] {
+					cells[o] = struct{}{}
+					free = true
+				}
+			}
+			stuck = stuck || !free
+		}
+		return len(stray) > 0
+	}
+
+	origin := make([][]engine.Coordinates, len(b.grid))
+	for r := range origin {
+		origin[r] = make([]engine.Coordinates, len(b.grid[r]))
+		for c := range origin[r] {
+			origin[r][c] = engine.Coordinates{Row: r, Col: c}
+		}
+	}
+	mark(b.grid, origin, engine.FindAllWords(b.rules, b.grid, b.wordMap), true)
+
+	visited := make(map[string]struct{})
+	var explore func(grid engine.Grid, origin [][]engine.Coordinates, found engine.FoundWordsSet, depth int)
+	explore = func(grid engine.Grid, origin [][]engine.Coordinates, found engine.FoundWordsSet, depth int) {
+		if depth >= b.rules.MaxTurns || len(b.used)+len(words) > b.maxWords {
+			return
+		}
+		key := engine.StateKey(engine.GameState{Grid: grid, FoundWords: found}, depth)
+		if _, ok := visited[key]; ok {
+			return
+		}
+		visited[key] = struct{}{}
+		for r := range grid {
+			for c := range grid[r] {
+				for _, to := range []engine.Coordinates{{Row: r, Col: c + 1}, {Row: r + 1, Col: c}} {
+					if to.Row >= len(grid) || to.Col >= len(grid[r]) || grid[r][c] == grid[to.Row][to.Col] {
+						continue
+					}
+					move := engine.Move{Cell1: engine.Coordinates{Row: r, Col: c}, Cell2: to}
+					next := engine.ApplyMove(grid, move)
+					newWords := engine.FindNewWords(b.rules, next, move, b.wordMap, found)
+					if len(newWords) == 0 {
+						continue
+					}
+					nextOrigin := make([][]engine.Coordinates, len(origin))
+					for i := range origin {
+						nextOrigin[i] = slices.Clone(origin[i])
+					}
+					nextOrigin[r][c], nextOrigin[to.Row][to.Col] = nextOrigin[to.Row][to.Col], nextOrigin[r][c]
+					if mark(next, nextOrigin, newWords, false) && !follow {
+						continue
+					}
+					nextFound := engine.CopyFoundWords(found)
+					for _, word := range newWords {
+						nextFound[word] = struct{}{}
+					}
+					explore(next, nextOrigin, nextFound, depth+1)
+				}
+			}
+		}
+	}
+	explore(b.grid, origin, make(engine.FoundWordsSet), 0)
+	return words, cells, stuck
+}
+
+// spell returns the word the span spells in grid.
+func spell(grid engine.Grid, span gridSpan, length int) string {
+	letters := make([]rune, length)
+	for i := range length {
+		p := span.cell(i)
+		letters[i] = grid[p.Row][p.Col]
+	}
+	return string(letters)
+}
+
+// repair redraws the free cells that spell words outside the chain until the chain and its
+// strays, all of them simple words, fit in maxWords. Each cell gets the best of fillerDraws
+// letters, so the filler settles on letters that spell nothing. It returns false if the locked
+// cells alone spell a stray or the strays do not settle within chainRepairs rounds.
+func (b *chainBuilder) repair(simpleWordMap engine.Dictionary) bool {
+	words, cells, stuck := b.strays(true)
+	for range chainRepairs {
+		if len(b.used)+len(words) <= b.maxWords && engine.ContainsAll(simpleWordMap, words) &&
+			len(engine.FindAllWords(b.rules, b.grid, b.wordMap)) == 0 && b.replays() {
+			return true
+		}
+		if stuck || len(cells) == 0 {
+			return false
+		}
+		// Cells are redrawn in a fixed order, so that a seeded source always builds the same grid.
+		for _, p := range slices.SortedFunc(maps.Keys(cells), func(a, b engine.Coordinates) int {
+			return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+		}) {
+			best, bestWords, bestCells, bestStuck := b.grid[p.Row][p.Col], words, cells, stuck
+			for range fillerDraws {
+				b.grid[p.Row][p.Col] = b.letters.random(b.rng)
+				if w, c, s := b.strays(true); len(w) < len(bestWords) {
+					best, bestWords, bestCells, bestStuck = b.grid[p.Row][p.Col], w, c, s
+				}
+			}
+			b.grid[p.Row][p.Col] = best
+			words, cells, stuck = bestWords, bestCells, bestStuck
+		}
+	}
+	return false
+}
+
+// plantChain builds a grid from which the chain can be played forwards: ChainWords if set, or
+// RequiredMinTurns words picked from the simple word list. Cells the chain does not need hold
+// filler letters, redrawn by repair until few words can be found off the chain. It returns nil
+// if the chain could not be planted.
+func (cmd *GenerateCmd) plantChain(rng *rand.Rand, rules engine.Rules, wordMap, simpleWordMap engine.Dictionary) engine.Grid {
+	letters := cmd.dicts.Letters()
+	grid := generateGrid(rng, letters, cmd.GridRows, cmd.GridCols)
+	b := newChainBuilder(rng, letters, grid, rules, wordMap, cmd.MaxUniqueWords)
+	steps := cmd.RequiredMinTurns
+	if len(cmd.ChainWords) > 0 {
+		steps = len(cmd.ChainWords)
+	}
+	for j := steps - 1; j >= 0; j-- {
+		candidates := cmd.chain
+		if len(cmd.ChainWords) > 0 {
+			candidates = cmd.ChainWords[j : j+1]
+		}
+		if !b.step(candidates) {
+			return nil
+		}
+	}
+	if !b.repair(simpleWordMap) {
+		return nil
+	}
+	return grid
+}
+
+// checkChain prepares the words chains are built from and checks that they can fit the grid.
+func (cmd *GenerateCmd) checkChain(simple *engine.Trie, wordMap engine.Dictionary) error {
+	if cmd.WordLength > max(cmd.GridRows, cmd.GridCols) {
+		return fmt.Errorf("words of length %d do not fit a %dx%d grid", cmd.WordLength, cmd.GridRows, cmd.GridCols)
+	}
+	for i, word := range cmd.ChainWords {
+		word = engine.NormalizeWord(word)
+		cmd.ChainWords[i] = word
+		if len([]rune(word)) != cmd.WordLength {
+			return fmt.Errorf("--chain-words: %q is not %d letters long", word, cmd.WordLength)
+		}
+		if !wordMap.Contains(word) {
+			return fmt.Errorf("--chain-words: %q is not in the dictionary", word)
+		}
+	}
+	cmd.chain = nil
+	excluded := cmd.excludedAffixes(cmd.dicts.Language())
+	for _, word := range simple.WordsOfLength(cmd.WordLength) {
+		if form, ok := cmd.dicts.Form(word); !ok || !form.Derived(excluded) {
+			cmd.chain = append(cmd.chain, word)
+		}
+	}
+	if len(cmd.chain) == 0 {
+		return fmt.Errorf("the simple word list has no words of length %d the filters allow", cmd.WordLength)
+	}
+	return nil
+}
+
+// constructGrid looks for an acceptable grid by planting word chains with plantChain. The chain
+// guarantees a line of play at least as deep as the chain, and the full exploration decides
+// whether the grid meets the turn and word limits.
+func (cmd *GenerateCmd) constructGrid(
+	ctx context.Context,
+	rng *rand.Rand,
+	rules engine.Rules,
+	wordMap engine.Dictionary,
+	simpleWordMap engine.Dictionary,
+	gridAttemptsTotal *int64,
+	gridAbortsTotal *int64,
+) (WorkerResult, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return WorkerResult{}, context.Cause(ctx)
+		}
+		atomic.AddInt64(gridAttemptsTotal, 1)
+		grid := cmd.plantChain(rng, rules, wordMap, simpleWordMap)
+		if grid == nil {
+			continue
+		}
+		result, ok, err := cmd.evaluate(ctx, rules, grid, wordMap, simpleWordMap)
+		if errors.Is(err, engine.ErrBudgetExceeded) {
+			atomic.AddInt64(gridAbortsTotal, 1)
+			continue
+		} else if err != nil {
+			return WorkerResult{}, err
+		}
+		if ok && cmd.accepts(result) {
+			return result, nil
+		}
+	}
+}
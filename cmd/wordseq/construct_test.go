@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// playsChain reports whether some line of play through nodes forms the words of chain in order.
+func playsChain(nodes []engine.ExplorationNode, chain []string) bool {
+	if len(chain) == 0 {
+		return true
+	}
+	for _, node := range nodes {
+		if slices.Contains(node.WordsFormed, chain[0]) && playsChain(node.NextMoves, chain[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPlantChain(t *testing.T) {
+	dicts, err := newDictionaries(DictionaryFlags{Lang: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCmd := func(chain ...string) *GenerateCmd {
+		return &GenerateCmd{
+			RulesFlags:       RulesFlags{WordLength: 4, RequiredMaxTurns: 4},
+			GridRows:         4,
+			GridCols:         4,
+			RequiredMinTurns: 3,
+			MaxUniqueWords:   8,
+			ChainWords:       chain,
+			dicts:            dicts,
+		}
+	}
+
+	// Chain words are normalized, and a chain is picked from the simple word list if none is given.
+	for _, cmd := range []*GenerateCmd{newCmd("Tack", "Fact", "Face"), newCmd()} {
+		if err := cmd.checkChain(dicts.Simple(), dicts.Words()); err != nil {
+			t.Fatal(err)
+		}
+		rules := cmd.Rules()
+		planted := 0
+		for seed := range uint64(20) {
+			grid := cmd.plantChain(rand.New(rand.NewPCG(seed, 0)), rules, dicts.Words(), dicts.Simple())
+			if grid == nil {
+				continue
+			}
+			planted++
+			if words := engine.FindAllWords(rules, grid, dicts.Words()); len(words) > 0 {
+				t.Errorf("%s: the planted grid already spells %v", engine.GridToString(grid), words)
+			}
+			tree, maxDepth := engine.Solve(rules, grid, dicts.Words())
+			if maxDepth < cmd.RequiredMinTurns {
+				t.Errorf("%s: the planted grid lasts %d moves, want at least %d", engine.GridToString(grid), maxDepth, cmd.RequiredMinTurns)
+			}
+			if len(cmd.ChainWords) > 0 && !playsChain(tree, cmd.ChainWords) {
+				t.Errorf("%s: no line of play forms %v", engine.GridToString(grid), cmd.ChainWords)
+			}
+		}
+		if planted == 0 {
+			t.Errorf("chain %v: no seed planted a grid", cmd.ChainWords)
+		}
+	}
+}
+
+func TestCheckChain(t *testing.T) {
+	dicts, err := newDictionaries(DictionaryFlags{Lang: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		rows, cols int
+		chain      []string
+	}{
+		{3, 3, nil},
+		{4, 4, []string{"cakes"}},
+		{4, 4, []string{"qzxv"}},
+	} {
+		cmd := &GenerateCmd{RulesFlags: RulesFlags{WordLength: 4}, GridRows: tt.rows, GridCols: tt.cols, ChainWords: tt.chain, dicts: dicts}
+		if err := cmd.checkChain(dicts.Simple(), dicts.Words()); err == nil {
+			t.Errorf("checkChain accepted %v on a %dx%d grid", tt.chain, tt.rows, tt.cols)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// ConvertCmd rewrites level files between the tree and DAG layouts.
+type ConvertCmd struct {
+	To  string `kong:"name='to',required,enum='tree,dag',help='Layout to convert to.'"`
+	Src string `kong:"arg,name='src',type='existingfile|existingdir',help='Level file or directory of level files to convert.'"`
+	Dst string `kong:"arg,name='dst',help='File or directory to write the converted levels to. May be the same as src to convert in place.'"`
+}
+
+// Run converts cmd.Src into cmd.Dst, mirroring the directory layout.
+func (cmd *ConvertCmd) Run() error {
+	info, err := os.Stat(cmd.Src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return convertLevelFile(cmd.Src, cmd.Dst, cmd.To)
+	}
+
+	converted := 0
+	var before, after int64
+	err = walkLevels(cmd.Src, func(path string, level *engine.FullExplorationOutput) error {
+		rel, err := filepath.Rel(cmd.Src, path)
+		if err != nil {
+			return err
+		}
+		srcInfo, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		jsonData, err := marshalLevel(level, cmd.To)
+		if err != nil {
+			return fmt.Errorf("marshaling '%s': %w", path, err)
+		}
+		if err := writeFileAtomic(filepath.Join(cmd.Dst, rel), jsonData, 0644); err != nil {
+			return err
+		}
+		converted++
+		before += srcInfo.Size()
+		after += int64(len(jsonData))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Converted %d levels to %s: %d bytes -> %d bytes.\n", converted, cmd.To, before, after)
+	return nil
+}
+
+func convertLevelFile(src, dst, format string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	level, err := engine.ParseLevel(data)
+	if err != nil {
+		return fmt.Errorf("parsing '%s': %w", src, err)
+	}
+	jsonData, err := marshalLevel(level, format)
+	if err != nil {
+		return fmt.Errorf("marshaling '%s': %w", src, err)
+	}
+	if err := writeFileAtomic(dst, jsonData, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Converted %s to %s: %d bytes -> %d bytes.\n", src, format, len(data), len(jsonData))
+	return nil
+}
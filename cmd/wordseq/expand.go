@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ExpandCmd writes the words of the Hunspell dictionary of the language pack, one per line, the
+// way `unmunch en.dic en.aff` would.
+type ExpandCmd struct {
+	MinLength int    `kong:"name='min-length',help='Only write words of at least this many letters.'"`
+	MaxLength int    `kong:"name='max-length',help='Only write words of at most this many letters.'"`
+	Output    string `kong:"name='output',short='o',default='-',help='File to write the words to, or - for stdout.'"`
+}
+
+// Run expands the dictionary with the global dictionary flags and the length limits of cmd.
+func (cmd *ExpandCmd) Run(dicts *Dictionaries) error {
+	opts := dicts.flags.expandOptions()
+	opts.MinLength, opts.MaxLength = cmd.MinLength, cmd.MaxLength
+	forms, err := expandDictionary(dicts.Language().dic, dicts.Language().aff, opts)
+	if err != nil {
+		return err
+	}
+	words := formWords(forms)
+
+	if cmd.Output == "-" {
+		return writeWords(os.Stdout, words)
+	}
+	f, err := os.Create(cmd.Output)
+	if err != nil {
+		return err
+	}
+	if err := writeWords(f, words); err != nil {
+		f.Close()
+		return fmt.Errorf("writing '%s': %w", cmd.Output, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d words to %s.\n", len(words), cmd.Output)
+	return nil
+}
+
+func writeWords(w io.Writer, words []string) error {
+	bw := bufio.NewWriter(w)
+	for _, word := range words {
+		bw.WriteString(word)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
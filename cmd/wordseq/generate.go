@@ -0,0 +1,548 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// GenerateCmd generates daily levels from random grids.
+type GenerateCmd struct {
+	RulesFlags      `kong:"embed"`
+	BudgetFlags     `kong:"embed"`
+	FilterFlags     `kong:"embed"`
+	SimulationFlags `kong:"embed"`
+
+	GridRows         int             `kong:"name='grid-rows',short='r',default='5',help='Number of rows in the grid.'"`
+	GridCols         int             `kong:"name='grid-cols',short='c',default='5',help='Number of columns in the grid.'"`
+	RequiredMinTurns int             `kong:"name='min-turns',short='t',default='7',help='Minimum number of turns required for a solvable puzzle.'"`
+	MaxUniqueWords   int             `kong:"name='max-unique-words',short='u',default='15',help='Maximum number of unique words to target in a puzzle solution.'"`
+	NumGrids         int             `kong:"name='num-grids',short='n',default='100',help='Number of grids to generate.'"`
+	Output           string          `kong:"name='output',short='o',default='output',help='Directory to output files to. The --lang code is inserted before its last element, so levels/normal becomes levels/en/normal.'"`
+	StartDate        DefaultableDate `kong:"name='start-date',short='s',help='Date to start at',format='2006-01-02'"`
+	EndDate          DefaultableDate `kong:"name='end-date',short='e',help='Last date to generate, inclusive. Overrides --num-grids.',format='2006-01-02'"`
+	Overwrite        bool            `kong:"name='overwrite',help='Regenerate every date in the range, replacing existing level files. Without it, dates that already have a level file are skipped, so a run fills the gaps in the calendar and resumes where an interrupted run stopped.'"`
+	Seed             uint64          `kong:"name='seed',help='Base seed for generation. Each date derives its own seed from this, the difficulty and the date. 0 picks a random seed.'"`
+	Difficulty       string          `kong:"name='difficulty',help='Difficulty name mixed into the per-date seeds. Defaults to the base name of --output.'"`
+	Format           string          `kong:"name='format',enum='tree,dag',default='tree',help='Layout of the level files: tree repeats shared subtrees, dag stores each state once.'"`
+	Config           string          `kong:"name='config',type='existingfile',help='YAML file of named difficulty profiles to generate in one run. Profile settings replace the grid, turn, word and output flags.'"`
+	Profiles         []string        `kong:"name='profile',help='Only generate these profiles from --config.'"`
+	Search           string          `kong:"name='search',enum='random,climb,anneal,construct',default='random',help='How to look for grids: random draws a fresh grid every time, climb and anneal mutate promising grids toward the required turns and word limit, construct plants a chain of words and scrambles it backwards.'"`
+	SearchSteps      int             `kong:"name='search-steps',default='300',help='Mutations to try from one starting grid with --search=climb or anneal before starting over.'"`
+	ChainWords       []string        `kong:"name='chain-words',help='Words to plant with --search=construct, in the order they are played. Defaults to --min-turns words picked from the simple word list.'"`
+	Classify         bool            `kong:"name='classify',help='Fill the calendars of the --config profiles that share a grid size and rules from one stream of grids, giving each grid to the first profile whose filters and score range accept it.'"`
+
+	weights *engine.DifficultyWeights
+	players []engine.Strategy
+	chain   []string
+	dicts   *Dictionaries
+}
+
+// worker function processes grid generation and exploration. Each job is a grid index; the
+// grid for it is drawn from a random source seeded for that index's date, so the result does
+// not depend on which worker picks the job up or when.
+func worker(
+	ctx context.Context,
+	cmd *GenerateCmd,
+	id int,
+	wg *sync.WaitGroup,
+	rules engine.Rules,
+	wordMap engine.Dictionary,
+	simpleWordMap engine.Dictionary,
+	jobsChan <-chan int,
+	resultsChan chan<- WorkerResult,
+	gridAttemptsTotal *int64,
+	gridAbortsTotal *int64,
+) {
+	defer wg.Done()
+	fmt.Printf("Worker %d started\n", id)
+	for gridIndex := range jobsChan {
+		seed := deriveSeed(cmd.Seed, cmd.Difficulty, cmd.gridDate(gridIndex))
+		rng := rand.New(rand.NewPCG(seed, 0))
+		if cmd.Search != searchRandom {
+			search := cmd.searchGrid
+			if cmd.Search == searchConstruct {
+				search = cmd.constructGrid
+			}
+			result, err := search(ctx, rng, rules, wordMap, simpleWordMap, gridAttemptsTotal, gridAbortsTotal)
+			if err != nil {
+				fmt.Printf("Worker %d stopping: %v\n", id, err)
+				return
+			}
+			result.GridIndex = gridIndex
+			select {
+			case resultsChan <- result:
+			case <-ctx.Done():
+				fmt.Printf("Worker %d stopping before sending result: %v\n", id, context.Cause(ctx))
+				return
+			}
+			continue
+		}
+		for {
+			select {
+			case <-ctx.Done(): // Check if we need to stop
+				fmt.Printf("Worker %d stopping: %v\n", id, context.Cause(ctx))
+				return
+			default:
+				// Continue processing
+			}
+
+			initialGrid := generateGrid(rng, cmd.dicts.Letters(), cmd.GridRows, cmd.GridCols)
+			if initialGrid == nil {
+				continue
+			}
+
+			atomic.AddInt64(gridAttemptsTotal, 1)
+
+			result, ok, err := cmd.evaluate(ctx, rules, initialGrid, wordMap, simpleWordMap)
+			if errors.Is(err, engine.ErrBudgetExceeded) {
+				atomic.AddInt64(gridAbortsTotal, 1)
+				continue
+			} else if err != nil {
+				fmt.Printf("Worker %d stopping: %v\n", id, err)
+				return
+			}
+			if !ok || !cmd.accepts(result) {
+				continue
+			}
+			result.GridIndex = gridIndex
+
+			// If all checks pass, send the result
+			// Need to handle potential block if resultsChan is full or main is slow
+			select {
+			case resultsChan <- result:
+			case <-ctx.Done(): // If we need to stop while trying to send
+				fmt.Printf("Worker %d stopping before sending result: %v\n", id, context.Cause(ctx))
+				return
+			}
+			break
+		}
+	}
+}
+
+// evaluate explores a candidate grid and measures it. It returns false for grids no level of
+// this shape may use: grids that spell a word before any move, and grids whose words are not
+// all in the simple word list. Budget errors are returned so the caller can count the grid as
+// abandoned.
+func (cmd *GenerateCmd) evaluate(ctx context.Context, rules engine.Rules, grid engine.Grid, wordMap, simpleWordMap engine.Dictionary) (WorkerResult, bool, error) {
+	if initialWordsCheck := engine.FindAllWords(rules, grid, wordMap); len(initialWordsCheck) > 0 {
+		return WorkerResult{}, false, nil
+	}
+
+	// Each call to Solve uses its own exploration cache for the grid it's currently processing
+	explorationTree, maxDepth, err := engine.SolveContext(ctx, rules, grid, wordMap, engine.SolveOptions{Budget: cmd.Budget()})
+	if err != nil {
+		return WorkerResult{}, false, err
+	}
+
+	wordSet := make(engine.FoundWordsSet)
+	engine.CollectAllWords(explorationTree, wordSet)
+	if !engine.ContainsAll(simpleWordMap, wordSet) {
+		return WorkerResult{}, false, nil
+	}
+
+	metrics := engine.ComputeMetrics(explorationTree, maxDepth, rules.MaxTurns)
+	return WorkerResult{
+		Grid:            grid,
+		ExplorationTree: explorationTree,
+		Words:           wordSet,
+		MaxDepth:        maxDepth,
+		Metrics:         metrics,
+		Classification:  engine.Classify(metrics, maxDepth, rules.MaxTurns, wordSet, cmd.dicts.Letters().rarity, cmd.difficultyWeights()),
+		Simulations:     cmd.simulate(cmd.players, grid, explorationTree, maxDepth),
+	}, true, nil
+}
+
+// accepts reports whether an evaluated grid meets the turn, word and filter requirements of cmd.
+func (cmd *GenerateCmd) accepts(result WorkerResult) bool {
+	return result.MaxDepth >= cmd.RequiredMinTurns &&
+		result.Metrics.UniqueWords <= cmd.MaxUniqueWords &&
+		cmd.Accept(result.Metrics, result.Classification, result.Simulations) &&
+		len(cmd.dicts.derivedWith(result.Words, cmd.excludedAffixes(cmd.dicts.Language()))) == 0
+}
+
+// searchSteps returns SearchSteps if the search mutates grids, and 0 otherwise.
+func (cmd *GenerateCmd) searchSteps() int {
+	if cmd.Search != searchClimb && cmd.Search != searchAnneal {
+		return 0
+	}
+	return cmd.SearchSteps
+}
+
+// difficultyWeights returns the weights of the difficulty score, from the config file if it set any.
+func (cmd *GenerateCmd) difficultyWeights() engine.DifficultyWeights {
+	if cmd.weights == nil {
+		return engine.DefaultDifficultyWeights
+	}
+	return *cmd.weights
+}
+
+// gridDate returns the calendar date a grid index is published on.
+func (cmd *GenerateCmd) gridDate(gridIndex int) time.Time {
+	return cmd.StartDate.Time.AddDate(0, 0, gridIndex)
+}
+
+// deriveSeed derives the seed for one day's puzzle from the base seed, the difficulty and the
+// date, so any single day can be regenerated on its own.
+func deriveSeed(seed uint64, difficulty string, date time.Time) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%s", seed, difficulty, date.Format("2006-01-02"))
+	return h.Sum64()
+}
+
+// Run generates cmd.NumGrids levels, one per day starting at cmd.StartDate, either with the
+// settings given as flags or for every profile of the config file. Profiles generated in one run
+// share the base seed and the loaded dictionaries.
+func (cmd *GenerateCmd) Run(ctx context.Context, dicts *Dictionaries) error {
+	cmd.dicts = dicts
+	if cmd.StartDate.Time == nil {
+		if err := cmd.StartDate.UnmarshalText(nil); err != nil {
+			return err
+		}
+	}
+	if cmd.Seed == 0 {
+		cmd.Seed = rand.Uint64()
+	}
+	if cmd.EndDate.Time != nil {
+		days := calendarDays(*cmd.StartDate.Time, *cmd.EndDate.Time)
+		if days < 0 {
+			return fmt.Errorf("--end-date %s is before --start-date %s", cmd.EndDate, cmd.StartDate)
+		}
+		cmd.NumGrids = days + 1
+	}
+	players, err := cmd.strategies()
+	if err != nil {
+		return err
+	}
+	cmd.players = players
+	if cmd.Config == "" {
+		if len(cmd.Profiles) > 0 {
+			return errors.New("--profile requires --config")
+		}
+		if cmd.Classify {
+			return errors.New("--classify requires --config")
+		}
+		if cmd.Difficulty == "" {
+			cmd.Difficulty = filepath.Base(cmd.Output)
+		}
+		cmd.Output = dicts.Language().levelDir(cmd.Output)
+		return cmd.generate(ctx, dicts)
+	}
+
+	config, err := loadGenerateConfig(cmd.Config, cmd.Profiles)
+	if err != nil {
+		return err
+	}
+	cmd.weights = config.Weights
+	if cmd.Classify {
+		if cmd.NumGrids == -1 {
+			return errors.New("--classify needs a finite range: set --end-date or --num-grids")
+		}
+		if cmd.Search != searchRandom {
+			return errors.New("--classify only supports --search=random")
+		}
+		return cmd.generateClassified(ctx, dicts, config.Profiles)
+	}
+	for _, profile := range config.Profiles {
+		fmt.Printf("\n=== Profile %s ===\n", profile.Name)
+		if err := cmd.applyProfile(profile).generate(ctx, dicts); err != nil {
+			return fmt.Errorf("profile %s: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// generate runs the generation for a single difficulty, for the dates chosen by jobs.
+func (cmd *GenerateCmd) generate(ctx context.Context, dicts *Dictionaries) error {
+	if err := cmd.checkStrategies(cmd.players); err != nil {
+		return err
+	}
+	if cmd.NumGrids != -1 {
+		pending := 0
+		for range cmd.jobs() {
+			pending++
+		}
+		if pending == 0 {
+			fmt.Printf("All %d levels from %s already exist under %s.\n", cmd.NumGrids, cmd.StartDate, cmd.Output)
+			return nil
+		}
+		fmt.Printf("%d of %d levels from %s need generating under %s.\n", pending, cmd.NumGrids, cmd.StartDate, cmd.Output)
+	}
+
+	fmt.Printf("Grid Dimensions: %d rows, %d columns\n", cmd.GridRows, cmd.GridCols)
+	fmt.Printf("Word Length: %d\n", cmd.WordLength)
+	fmt.Printf("Required Turns: %d-%d\n", cmd.RequiredMinTurns, cmd.RequiredMaxTurns)
+	fmt.Printf("Max Unique Words: %d\n", cmd.MaxUniqueWords)
+	fmt.Printf("Grids to Generate: %d\n", cmd.NumGrids)
+	fmt.Printf("Grid Search: %s\n", cmd.Search)
+	fmt.Printf("Seed: %d (difficulty %q, starting %s)\n", cmd.Seed, cmd.Difficulty, cmd.StartDate)
+
+	// --- Load Dictionary ---
+	fmt.Println("Loading dictionary...")
+	wordMap := dicts.Words()
+	simpleWordMap := dicts.Simple()
+	fmt.Printf("Dictionary loaded with %d words (%d of length %d).\n", wordMap.Len(), wordMap.LenOfLength(cmd.WordLength), cmd.WordLength)
+	if cmd.Search == searchConstruct {
+		if err := cmd.checkChain(simpleWordMap, wordMap); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Grid size: %d x %d\n", cmd.GridRows, cmd.GridCols)
+	fmt.Printf("Word length: %d\n", cmd.WordLength)
+	fmt.Printf("Required minimum game tree depth: %d\n", cmd.RequiredMinTurns)
+	fmt.Printf("Maximum exploration depth: %d\n", cmd.RequiredMaxTurns)
+	fmt.Printf("Maximum unique words allowed: %d\n", cmd.MaxUniqueWords)
+
+	// --- Parallel Grid Generation and Search Loop ---
+	startTime := time.Now()
+	foundSuitable := false // Tracks if any suitable grid is found at all
+	validGridsFound := 0   // Counter for grids that passed all filters and were written
+
+	numWorkers := runtime.NumCPU()
+	fmt.Printf("Using %d worker goroutines.\n", numWorkers)
+
+	// Workers stop when the run is interrupted or once every job is done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobsChan := make(chan int)
+	resultsChan := make(chan WorkerResult, numWorkers) // Buffered channel
+	var wg sync.WaitGroup
+	var gridAttemptsTotal int64 // Atomic counter for total attempts
+	var gridAbortsTotal int64   // Atomic counter for grids abandoned over their budget
+
+	rules := cmd.Rules()
+
+	// Launch workers
+	for i := 0; i < numWorkers; i++ { // Corrected loop condition
+		wg.Add(1)
+		go worker(ctx, cmd, i, &wg, rules, wordMap, simpleWordMap, jobsChan, resultsChan, &gridAttemptsTotal, &gridAbortsTotal)
+	}
+
+	// Hand out one job per date. Workers exit once the jobs run out.
+	go func() {
+		defer close(jobsChan)
+		for i := range cmd.jobs() {
+			select {
+			case jobsChan <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Goroutine to close resultsChan once all workers are done processing and have exited.
+	// This signals the results processing loop below to terminate.
+	go func() {
+		wg.Wait()
+		close(resultsChan) // Close the channel after all workers are done.
+		fmt.Println("All workers finished, results channels closed.")
+	}()
+
+	// Progress ticker
+	ticker := time.NewTicker(10 * time.Second) // Print progress every 10 seconds
+	defer ticker.Stop()
+
+	// Main loop to collect results and manage workers. Results that arrive after an interrupt
+	// are still written, since every file is written atomically.
+	interrupted := ctx.Done()
+resultsLoop:
+	for {
+		select {
+		case result, ok := <-resultsChan:
+			if !ok { // resultsChan was closed by the goroutine above
+				fmt.Println("Results channel closed, exiting results loop.")
+				break resultsLoop
+			}
+			// Process valid result
+			if !foundSuitable {
+				foundSuitable = true
+			}
+			if err := cmd.WriteOutput(result); err != nil {
+				fmt.Printf("Error writing grid index %d: %v\n", result.GridIndex, err)
+				cancel()
+				continue
+			}
+			validGridsFound++
+
+		case <-interrupted:
+			fmt.Println("Interrupted. Waiting for workers to stop...")
+			interrupted = nil // Keep draining results until the workers have exited
+
+		case <-ticker.C:
+			attempts := atomic.LoadInt64(&gridAttemptsTotal)
+			aborts := atomic.LoadInt64(&gridAbortsTotal)
+			fmt.Printf("...elapsed: %v, checked ~%d grids (found %d valid, abandoned %d over budget)\n",
+				time.Since(startTime).Round(time.Second), attempts, validGridsFound, aborts)
+		}
+	}
+
+	// Final summary
+	elapsedTime := time.Since(startTime).Round(time.Second)
+	finalAttempts := atomic.LoadInt64(&gridAttemptsTotal)
+	if !foundSuitable {
+		fmt.Printf("\nSearch finished after %v (~%d attempts). No grid meeting all criteria found.\n", elapsedTime, finalAttempts)
+	} else {
+		fmt.Printf("\nSearch finished after %v (~%d attempts).\n", elapsedTime, finalAttempts)
+		fmt.Printf("Found and saved %d grids meeting all criteria.\n", validGridsFound)
+	}
+	if aborts := atomic.LoadInt64(&gridAbortsTotal); aborts > 0 {
+		fmt.Printf("Abandoned %d grids that exceeded the exploration budget.\n", aborts)
+	}
+	if err := ctx.Err(); err != nil {
+		fmt.Println("Run stopped early. Rerun the same command to generate the remaining dates.")
+		return err
+	}
+	return nil
+}
+
+// jobs yields the grid indices to generate: the dates in the range without a level file, so an
+// interrupted run resumes where it stopped and never replaces a published level. With Overwrite
+// every date in the range is generated.
+func (cmd *GenerateCmd) jobs() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; cmd.NumGrids == -1 || i < cmd.NumGrids; i++ {
+			if !cmd.Overwrite {
+				if _, err := os.Stat(levelPath(cmd.Output, cmd.gridDate(i))); err == nil {
+					continue
+				}
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// generateConfig is the effective configuration of a generate run as recorded in level provenance.
+type generateConfig struct {
+	Command          string `json:"command"`
+	Lang             string `json:"lang"`
+	GridRows         int    `json:"gridRows"`
+	GridCols         int    `json:"gridCols"`
+	WordLength       int    `json:"wordLength"`
+	RequiredMinTurns int    `json:"minTurns"`
+	RequiredMaxTurns int    `json:"maxTurns"`
+	MaxUniqueWords   int    `json:"maxUniqueWords"`
+	StartDate        string `json:"startDate"`
+	Format           string `json:"format"`
+	FilterFlags
+	MaxNodes          int64                    `json:"maxNodes,omitempty"`
+	MaxCacheEntries   int64                    `json:"maxCacheEntries,omitempty"`
+	GridTimeout       string                   `json:"gridTimeout,omitempty"`
+	DifficultyWeights engine.DifficultyWeights `json:"difficultyWeights"`
+	SimStrategies     []string                 `json:"simStrategies"`
+	SimGames          int                      `json:"simGames"`
+	Search            string                   `json:"search,omitempty"`
+	SearchSteps       int                      `json:"searchSteps,omitempty"`
+	ChainWords        []string                 `json:"chainWords,omitempty"`
+	Classify          bool                     `json:"classify,omitempty"`
+}
+
+func (cmd *GenerateCmd) effectiveConfig() generateConfig {
+	return generateConfig{
+		Command:           "generate",
+		Lang:              cmd.dicts.Language().Code,
+		GridRows:          cmd.GridRows,
+		GridCols:          cmd.GridCols,
+		WordLength:        cmd.WordLength,
+		RequiredMinTurns:  cmd.RequiredMinTurns,
+		RequiredMaxTurns:  cmd.RequiredMaxTurns,
+		MaxUniqueWords:    cmd.MaxUniqueWords,
+		StartDate:         cmd.StartDate.String(),
+		Format:            cmd.Format,
+		FilterFlags:       cmd.FilterFlags,
+		MaxNodes:          cmd.MaxNodes,
+		MaxCacheEntries:   cmd.MaxCacheEntries,
+		GridTimeout:       durationString(cmd.GridTimeout),
+		DifficultyWeights: cmd.difficultyWeights(),
+		SimStrategies:     cmd.Strategies,
+		SimGames:          cmd.SimGames,
+		Search:            cmd.Search,
+		SearchSteps:       cmd.searchSteps(),
+		ChainWords:        cmd.ChainWords,
+		Classify:          cmd.Classify,
+	}
+}
+
+// durationString formats d for the provenance config, leaving unset durations empty.
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// WriteOutput handles formatting and writing the JSON data for a single valid grid.
+func (cmd *GenerateCmd) WriteOutput(result WorkerResult) error {
+	provenance, err := newProvenance(cmd.dicts, cmd.effectiveConfig(), cmd.Seed, cmd.Difficulty)
+	if err != nil {
+		return fmt.Errorf("recording provenance: %w", err)
+	}
+	provenance.GridSeed = result.GridSeed
+	outputData := engine.FullExplorationOutput{
+		LevelInfo: engine.LevelInfo{
+			SchemaVersion:    engine.SchemaVersion,
+			Language:         cmd.dicts.Language().Code,
+			InitialGrid:      engine.ConvertGridToJsonGrid(result.Grid),
+			WordLength:       cmd.WordLength,
+			RequiredMinTurns: cmd.RequiredMinTurns,
+			RequiredMaxTurns: cmd.RequiredMaxTurns,
+			MaxDepthReached:  result.MaxDepth,
+			Metrics:          &result.Metrics,
+			Classification:   &result.Classification,
+			Simulations:      result.Simulations,
+			Stems:            cmd.dicts.Stems(result.Words),
+			Provenance:       provenance,
+		},
+		ExplorationTree: result.ExplorationTree,
+	}
+	jsonData, err := marshalLevel(&outputData, cmd.Format)
+	if err != nil {
+		return fmt.Errorf("marshaling JSON: %w", err)
+	}
+
+	outputFilename := levelPath(cmd.Output, cmd.gridDate(result.GridIndex))
+	if err := writeFileAtomic(outputFilename, jsonData, 0644); err != nil {
+		return err
+	}
+
+	allWordsList := make([]string, 0, len(result.Words))
+	for word := range result.Words {
+		allWordsList = append(allWordsList, word)
+	}
+	sort.Strings(allWordsList)
+
+	fmt.Printf("\n--- Found Valid Grid (%d) ---\n", result.GridIndex)
+	printGrid(os.Stdout, result.Grid)
+	fmt.Printf("  File Path:                %s\n", outputFilename)
+	fmt.Printf("  Seed:                     %d (%s)\n", cmd.Seed, cmd.Difficulty)
+	fmt.Printf("  Grid Dimensions:          %d x %d\n", cmd.GridRows, cmd.GridCols)
+	fmt.Printf("  Word Length:              %d\n", cmd.WordLength)
+	fmt.Printf("  Required Min Tree Depth:  %d\n", cmd.RequiredMinTurns)
+	fmt.Printf("  Max Exploration Depth:    %d\n", cmd.RequiredMaxTurns)
+	fmt.Printf("  Actual Max Depth Reached: %d\n", result.MaxDepth)
+	fmt.Printf("  Possible First Moves:     %d\n", result.Metrics.FirstMoves)
+	fmt.Printf("  Total Unique Words Found: %d\n", len(allWordsList))
+	if len(allWordsList) > 0 {
+		fmt.Printf("  Words Found:              %s\n", strings.Join(allWordsList, ", "))
+	}
+	printMetrics(os.Stdout, result.Metrics)
+	printClassification(os.Stdout, result.Classification)
+	printSimulations(os.Stdout, result.Simulations)
+	fmt.Println("---------------------------")
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestJobs(t *testing.T) {
+	start := time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)
+	cmd := &GenerateCmd{Output: t.TempDir(), NumGrids: 5, StartDate: DefaultableDate{Time: &start}}
+	for _, i := range []int{0, 2, 3} {
+		if err := writeFileAtomic(levelPath(cmd.Output, cmd.gridDate(i)), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := slices.Collect(cmd.jobs()); !slices.Equal(got, []int{1, 4}) {
+		t.Errorf("jobs() = %v, want [1 4]", got)
+	}
+	cmd.Overwrite = true
+	if got := slices.Collect(cmd.jobs()); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("jobs() with Overwrite = %v, want [0 1 2 3 4]", got)
+	}
+	if _, err := os.Stat(levelPath(cmd.Output, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))); err != nil {
+		t.Errorf("the level of index 2 is not at 2025/06/01: %v", err)
+	}
+}
+
+func TestDeriveSeed(t *testing.T) {
+	date := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	seed := deriveSeed(42, "normal", date)
+	if again := deriveSeed(42, "normal", date); again != seed {
+		t.Errorf("deriveSeed gave %d, then %d for the same inputs", seed, again)
+	}
+	// The time of day and the location do not matter, only the date.
+	if local := deriveSeed(42, "normal", time.Date(2025, 5, 1, 18, 30, 0, 0, time.FixedZone("UTC-7", -7*3600))); local != seed {
+		t.Errorf("deriveSeed of a later time on the same date = %d, want %d", local, seed)
+	}
+
+	others := map[string]uint64{
+		"base seed":  deriveSeed(43, "normal", date),
+		"difficulty": deriveSeed(42, "hard", date),
+		"date":       deriveSeed(42, "normal", date.AddDate(0, 0, 1)),
+	}
+	for name, other := range others {
+		if other == seed {
+			t.Errorf("a different %s gave the same seed %d", name, seed)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// WorkerResult is used to send processed grid data from workers to the main goroutine.
+type WorkerResult struct {
+	GridIndex       int
+	GridSeed        uint64
+	Grid            engine.Grid
+	ExplorationTree []engine.ExplorationNode
+	Words           engine.FoundWordsSet
+	MaxDepth        int
+	Metrics         engine.Metrics
+	Classification  engine.Classification
+	Simulations     []engine.Simulation
+}
+
+func generateGrid(rng *rand.Rand, letters *letterDistribution, rows, cols int) engine.Grid {
+	if rows <= 0 || cols <= 0 {
+		return nil
+	}
+	grid := make(engine.Grid, rows)
+	for r := range grid {
+		grid[r] = make([]rune, cols)
+		for c := range grid[r] {
+			grid[r][c] = letters.random(rng)
+		}
+	}
+	return grid
+}
+
+// mutateGrid returns a copy of grid with one small change: usually one cell gets a new letter,
+// otherwise two cells holding different letters swap them. The copy is unchanged if the
+// distribution has a single letter and the grid is made of it.
+func mutateGrid(rng *rand.Rand, letters *letterDistribution, grid engine.Grid) engine.Grid {
+	mutated := engine.CopyGrid(grid)
+	rows, cols := len(grid), len(grid[0])
+	r1, c1 := rng.IntN(rows), rng.IntN(cols)
+	if rng.IntN(4) == 0 {
+		r2, c2 := rng.IntN(rows), rng.IntN(cols)
+		if mutated[r1][c1] != mutated[r2][c2] {
+			mutated[r1][c1], mutated[r2][c2] = mutated[r2][c2], mutated[r1][c1]
+			return mutated
+		}
+	}
+	if letter, ok := letters.randomOther(rng, mutated[r1][c1]); ok {
+		mutated[r1][c1] = letter
+	}
+	return mutated
+}
+
+func printGrid(w io.Writer, grid engine.Grid) {
+	if grid == nil {
+		fmt.Fprintln(w, "Grid is empty or nil.")
+		return
+	}
+	fmt.Fprintln(w, "--- Grid ---")
+	for _, row := range grid {
+		for _, cell := range row {
+			fmt.Fprintf(w, "%c ", cell)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "------------")
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+func TestMutateGrid(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	grid := engine.Grid{[]rune("aa"), []rune("aa")}
+
+	// A single letter leaves nothing to change the grid to.
+	single := newLetterDistribution(map[rune]float64{'a': 1})
+	for range 100 {
+		if mutated := mutateGrid(rng, single, grid); engine.GridToString(mutated) != engine.GridToString(grid) {
+			t.Fatalf("mutateGrid changed %s to %s with a single letter", engine.GridToString(grid), engine.GridToString(mutated))
+		}
+	}
+
+	// b is so rare that drawing it at random mostly fails.
+	skewed := newLetterDistribution(map[rune]float64{'a': 1e6, 'b': 1})
+	for range 100 {
+		mutated := mutateGrid(rng, skewed, grid)
+		if engine.GridToString(mutated) == engine.GridToString(grid) {
+			t.Fatalf("mutateGrid left %s unchanged", engine.GridToString(grid))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// languageFiles holds a directory per language pack, named after its code: the Hunspell stems
+// and affix rules in <code>.dic and <code>.aff, the simple word list in usable.txt and,
+// optionally, the letter distribution in letters.txt and the pack's name and affix classes in
+// pack.yaml.
+//
+//go:embed data
+var languageFiles embed.FS
+
+// Language describes a language pack: its word lists, the distribution grid letters are drawn
+// from and which of its affix classes form plurals and inflections.
+type Language struct {
+	Code string `yaml:"-"`
+	// Name defaults to the code.
+	Name string `yaml:"name"`
+	// PluralAffixes are the affix classes of the pack's affix file that form plurals.
+	PluralAffixes []string `yaml:"pluralAffixes"`
+	// InflectionAffixes are the affix classes that form inflections, plurals included.
+	InflectionAffixes []string `yaml:"inflectionAffixes"`
+
+	dic, aff, simple, letters string
+}
+
+// languageCodes returns the codes of the embedded language packs, sorted.
+func languageCodes() []string {
+	entries, err := languageFiles.ReadDir("data")
+	if err != nil {
+		// The directory is embedded, so it always exists.
+		panic(fmt.Sprintf("listing the embedded language packs: %v", err))
+	}
+	var codes []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			codes = append(codes, entry.Name())
+		}
+	}
+	return codes
+}
+
+// loadLanguage reads the files of the language pack with the given code.
+func loadLanguage(code string) (*Language, error) {
+	if info, err := fs.Stat(languageFiles, path.Join("data", code)); code == "" || strings.ContainsAny(code, "/.") || err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("unknown language %q, expected one of %s", code, strings.Join(languageCodes(), ", "))
+	}
+	read := func(name string, required bool) (string, error) {
+		data, err := languageFiles.ReadFile(path.Join("data", code, name))
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			return "", nil
+		} else if err != nil {
+			return "", fmt.Errorf("language %s: %w", code, err)
+		}
+		return string(data), nil
+	}
+	var lang Language
+	pack, err := read("pack.yaml", false)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal([]byte(pack), &lang); err != nil {
+		return nil, fmt.Errorf("language %s: parsing pack.yaml: %w", code, err)
+	}
+	lang.Code = code
+	if lang.Name == "" {
+		lang.Name = code
+	}
+	if lang.dic, err = read(code+".dic", true); err != nil {
+		return nil, err
+	}
+	if lang.aff, err = read(code+".aff", true); err != nil {
+		return nil, err
+	}
+	if lang.simple, err = read("usable.txt", true); err != nil {
+		return nil, err
+	}
+	if lang.letters, err = read("letters.txt", false); err != nil {
+		return nil, err
+	}
+	return &lang, nil
+}
+
+// levelDir returns where the levels of a difficulty go for this language: output with the
+// language code inserted before its last element, so that levels/normal becomes levels/en/normal.
+func (l *Language) levelDir(output string) string {
+	return filepath.Join(filepath.Dir(output), l.Code, filepath.Base(output))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadLanguage(t *testing.T) {
+	if codes := languageCodes(); !slices.Contains(codes, "en") {
+		t.Fatalf("languageCodes() = %v, want en among them", codes)
+	}
+	lang, err := loadLanguage("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang.Code != "en" || lang.Name != "English" || !slices.Equal(lang.PluralAffixes, []string{"S"}) {
+		t.Errorf("loadLanguage(en) = %s %q with plurals %v", lang.Code, lang.Name, lang.PluralAffixes)
+	}
+	if lang.dic == "" || lang.aff == "" || lang.simple == "" || lang.letters == "" {
+		t.Error("loadLanguage(en) left a word list or the letters empty")
+	}
+	for _, code := range []string{"", "xx", ".", "..", "en/..", "wordle.txt"} {
+		if _, err := loadLanguage(code); err == nil {
+			t.Errorf("loadLanguage(%q) accepted an unknown language", code)
+		}
+	}
+}
+
+func TestLevelDir(t *testing.T) {
+	lang := &Language{Code: "en"}
+	tests := map[string]string{
+		"levels/normal":                     "levels/en/normal",
+		"frontend/public/levels/impossible": "frontend/public/levels/en/impossible",
+		"output":                            "en/output",
+	}
+	for output, want := range tests {
+		if got := lang.levelDir(filepath.FromSlash(output)); got != filepath.FromSlash(want) {
+			t.Errorf("levelDir(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// letterDistribution draws grid letters in proportion to how often they occur in a language.
+type letterDistribution struct {
+	frequencies map[rune]float64
+	weighted    []rune
+	// max is the frequency of the most common letter.
+	max float64
+}
+
+func newLetterDistribution(frequencies map[rune]float64) *letterDistribution {
+	d := &letterDistribution{frequencies: frequencies}
+	var totalWeight float64
+	for _, freq := range frequencies {
+		totalWeight += freq
+		d.max = max(d.max, freq)
+	}
+	const scaleFactor = 1000
+	d.weighted = make([]rune, 0, len(frequencies)*scaleFactor)
+	// Build the table in a fixed order so that a seeded source always draws the same letters.
+	for _, letter := range slices.Sorted(maps.Keys(frequencies)) {
+		freq := frequencies[letter]
+		count := int((freq / totalWeight) * float64(len(frequencies)*scaleFactor))
+		if count == 0 && freq > 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			d.weighted = append(d.weighted, letter)
+		}
+	}
+	if len(d.weighted) == 0 {
+		fmt.Println("Warning: the letter distribution is empty, falling back to uniform random letters.")
+		d.frequencies, d.max = make(map[rune]float64), 1
+		for r := 'a'; r <= 'z'; r++ {
+			d.weighted = append(d.weighted, r)
+			d.frequencies[r] = 1
+		}
+	}
+	return d
+}
+
+// parseLetterFrequencies reads a letters.txt file of a language pack: one letter per line,
+// followed by its relative frequency.
+func parseLetterFrequencies(s string) (map[rune]float64, error) {
+	frequencies := make(map[rune]float64)
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		letterField := engine.NormalizeWord(fields[0])
+		if len(fields) != 2 || utf8.RuneCountInString(letterField) != 1 {
+			return nil, fmt.Errorf("line %d: expected a letter and its frequency", line)
+		}
+		freq, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || freq < 0 {
+			return nil, fmt.Errorf("line %d: invalid frequency %q", line, fields[1])
+		}
+		letter, _ := utf8.DecodeRuneInString(letterField)
+		frequencies[letter] = freq
+	}
+	return frequencies, scanner.Err()
+}
+
+// deriveLetterFrequencies counts how often each letter occurs in words, as a percentage of all
+// letters, for language packs that do not list their frequencies.
+func deriveLetterFrequencies(words []string) map[rune]float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, word := range words {
+		for _, letter := range engine.NormalizeWord(word) {
+			if unicode.IsLetter(letter) {
+				counts[letter]++
+				total++
+			}
+		}
+	}
+	frequencies := make(map[rune]float64, len(counts))
+	for letter, count := range counts {
+		frequencies[letter] = 100 * float64(count) / float64(total)
+	}
+	return frequencies
+}
+
+func (d *letterDistribution) random(rng *rand.Rand) rune {
+	return d.weighted[rng.IntN(len(d.weighted))]
+}
+
+// randomOther draws a letter other than old, in proportion to the frequencies like random. It
+// reports false if old is the only letter of the distribution.
+func (d *letterDistribution) randomOther(rng *rand.Rand, old rune) (rune, bool) {
+	const draws = 16
+	for range draws {
+		if letter := d.random(rng); letter != old {
+			return letter, true
+		}
+	}
+	// old takes up most of the table, so draw from the rest of it instead.
+	others := make([]rune, 0, len(d.weighted))
+	for _, letter := range d.weighted {
+		if letter != old {
+			others = append(others, letter)
+		}
+	}
+	if len(others) == 0 {
+		return old, false
+	}
+	return others[rng.IntN(len(others))], true
+}
+
+// rarity rates a word between 0 and 1 by how uncommon its letters are, so that words spelled
+// with letters like q, x and z count as rare.
+func (d *letterDistribution) rarity(word string) float64 {
+	var total float64
+	letters := 0
+	for _, letter := range word {
+		total += 1 - d.frequencies[letter]/d.max
+		letters++
+	}
+	if letters == 0 {
+		return 0
+	}
+	return total / float64(letters)
+}
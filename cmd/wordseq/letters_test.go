@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseLetterFrequencies(t *testing.T) {
+	// é is given with a combining accent, which is composed into a single letter.
+	got, err := parseLetterFrequencies("# letter frequency\nE 12.5\n\nt 9\né 0.5\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[rune]float64{'e': 12.5, 't': 9, 'é': 0.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLetterFrequencies = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"e", "e 1 2", "ab 1", "e x", "e -1"} {
+		if _, err := parseLetterFrequencies(bad); err == nil {
+			t.Errorf("parseLetterFrequencies(%q) accepted a bad line", bad)
+		}
+	}
+}
+
+func TestDeriveLetterFrequencies(t *testing.T) {
+	got := deriveLetterFrequencies([]string{"Tea", "ten", "it's"})
+	// Nine letters in all; the apostrophe is not counted.
+	want := map[rune]float64{'t': 300.0 / 9, 'e': 200.0 / 9, 'a': 100.0 / 9, 'n': 100.0 / 9, 'i': 100.0 / 9, 's': 100.0 / 9}
+	if len(got) != len(want) {
+		t.Fatalf("deriveLetterFrequencies = %v, want %v", got, want)
+	}
+	for letter, freq := range want {
+		if math.Abs(got[letter]-freq) > 1e-9 {
+			t.Errorf("frequency of %c = %v, want %v", letter, got[letter], freq)
+		}
+	}
+}
+
+func TestLetterDistribution(t *testing.T) {
+	d := newLetterDistribution(map[rune]float64{'e': 3, 't': 1, 'z': 0})
+	counts := make(map[rune]int)
+	for _, letter := range d.weighted {
+		counts[letter]++
+	}
+	if counts['z'] != 0 || counts['e'] != 3*counts['t'] {
+		t.Errorf("weighted table counts %v, want e three times as often as t and no z", counts)
+	}
+	if d.rarity("ee") != 0 || d.rarity("zz") != 1 || d.rarity("") != 0 {
+		t.Errorf("rarity: ee %v, zz %v, empty %v, want 0, 1 and 0", d.rarity("ee"), d.rarity("zz"), d.rarity(""))
+	}
+}
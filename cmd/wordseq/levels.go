@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// levelPath returns the path of the level published on date in a level directory.
+func levelPath(dir string, date time.Time) string {
+	return filepath.Join(dir, date.Format("2006/01/02.json"))
+}
+
+// levelDate returns the date a level file is published on, from its YYYY/MM/DD.json path below dir.
+func levelDate(dir, path string) (time.Time, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006/01/02.json", filepath.ToSlash(rel))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// calendarDays returns the number of calendar days from the date of start to the date of end,
+// each taken in its own location, so a daylight saving change in between does not shift it.
+func calendarDays(start, end time.Time) int {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(endDay.Sub(startDay) / (24 * time.Hour))
+}
+
+// walkLevels parses every level file under dir and calls fn with it.
+func walkLevels(dir string, fn func(path string, level *engine.FullExplorationOutput) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		level, err := engine.ParseLevel(data)
+		if err != nil {
+			return fmt.Errorf("parsing '%s': %w", path, err)
+		}
+		return fn(path, level)
+	})
+}
+
+// Level file layouts accepted by --format.
+const (
+	formatTree = "tree"
+	formatDag  = "dag"
+)
+
+// marshalLevel encodes a level as indented JSON in the given layout.
+func marshalLevel(level *engine.FullExplorationOutput, format string) ([]byte, error) {
+	switch format {
+	case formatTree, "":
+		return json.MarshalIndent(level, "", "  ")
+	case formatDag:
+		return json.MarshalIndent(level.ToDag(), "", "  ")
+	default:
+		return nil, fmt.Errorf("unknown level format %q", format)
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so
+// an interrupted run never leaves a truncated file behind. Missing directories are created.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory '%s': %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file in '%s': %w", dir, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once the rename succeeded
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing '%s': %w", tmp.Name(), err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("setting permissions on '%s': %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing '%s': %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("renaming '%s' to '%s': %w", tmp.Name(), path, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestCalendarDays(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		start, end time.Time
+		want       int
+	}{
+		{time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), 30},
+		{time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), -1},
+		// The clocks go forward on March 9 and back on November 2, 2025.
+		{time.Date(2025, 3, 8, 0, 0, 0, 0, newYork), time.Date(2025, 3, 10, 0, 0, 0, 0, newYork), 2},
+		{time.Date(2025, 11, 1, 0, 0, 0, 0, newYork), time.Date(2025, 11, 3, 0, 0, 0, 0, newYork), 2},
+		// A local default start date and a parsed end date of the same day.
+		{time.Date(2025, 3, 9, 0, 0, 0, 0, newYork), time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range tests {
+		if got := calendarDays(tt.start, tt.end); got != tt.want {
+			t.Errorf("calendarDays(%s, %s) = %d, want %d", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2025", "05", "01.json")
+	if err := writeFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "second" {
+		t.Errorf("ReadFile = %q, %v, want \"second\"", data, err)
+	}
+
+	// A directory in the way makes the rename fail after the data is written.
+	blocked := filepath.Join(dir, "2025", "05", "02.json")
+	if err := os.Mkdir(blocked, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(blocked, []byte("third"), 0644); err == nil {
+		t.Error("writeFileAtomic replaced a directory")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if len(names) != 2 {
+		t.Errorf("directory holds %q, want only 01.json and 02.json", names)
+	}
+}
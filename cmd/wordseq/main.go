@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/sudorandom/wordchain/engine"
+	"github.com/sudorandom/wordchain/hunspell"
+)
+
+type CLI struct {
+	DictionaryFlags `kong:"embed"`
+
+	Generate   GenerateCmd   `kong:"cmd,help='Generate daily levels from random grids.'"`
+	Solve      SolveCmd      `kong:"cmd,help='Explore a given grid and summarize how it plays.'"`
+	Validate   ValidateCmd   `kong:"cmd,help='Re-check level files against the level rules.'"`
+	Stats      StatsCmd      `kong:"cmd,help='Summarize a level directory.'"`
+	Simulate   SimulateCmd   `kong:"cmd,help='Play stored levels with simulated players and report how far they get.'"`
+	Serve      ServeCmd      `kong:"cmd,help='Serve level files and the solver over HTTP.'"`
+	Convert    ConvertCmd    `kong:"cmd,help='Convert level files between the tree and DAG layouts.'"`
+	Migrate    MigrateCmd    `kong:"cmd,help='Upgrade level files to the current schema version in place.'"`
+	CheckWords CheckWordsCmd `kong:"cmd,name='check-words',help='Report words of a word list that are missing from a dictionary.'"`
+	Expand     ExpandCmd     `kong:"cmd,name='expand-dictionary',help='Write out the words of the embedded Hunspell dictionary.'"`
+}
+
+// RulesFlags are the engine settings shared by every subcommand that explores grids.
+type RulesFlags struct {
+	WordLength       int `kong:"name='word-length',short='l',default='5',help='The exact length of a word to be considered valid.'"`
+	RequiredMaxTurns int `kong:"name='max-turns',short='T',default='15',help='Maximum number of turns allowed for a solvable puzzle.'"`
+}
+
+// Rules returns the engine rules configured by the flags.
+func (f RulesFlags) Rules() engine.Rules {
+	return engine.Rules{WordLength: f.WordLength, MaxTurns: f.RequiredMaxTurns}
+}
+
+// BudgetFlags limit the work spent exploring a single grid. They are unlimited by default; a
+// command embedding them can set the maxNodes, maxCacheEntries and gridTimeout vars to change that.
+type BudgetFlags struct {
+	MaxNodes        int64         `kong:"name='max-nodes',default='${maxNodes=0}',help='Give up on a grid after visiting about this many states. 0 means no limit.'"`
+	MaxCacheEntries int64         `kong:"name='max-cache-entries',default='${maxCacheEntries=0}',help='Give up on a grid once this many subtrees are memoized. 0 means no limit.'"`
+	GridTimeout     time.Duration `kong:"name='grid-timeout',default='${gridTimeout=0}',help='Give up on a grid after exploring it for this long, e.g. 30s. 0 means no limit.'"`
+}
+
+// Budget returns the exploration budget configured by the flags.
+func (f BudgetFlags) Budget() engine.Budget {
+	return engine.Budget{MaxNodes: f.MaxNodes, MaxCacheEntries: f.MaxCacheEntries, Timeout: f.GridTimeout}
+}
+
+// DictionaryFlags choose the language pack and the words its Hunspell dictionary expands to.
+type DictionaryFlags struct {
+	Lang            string   `kong:"name='lang',default='en',help='Language pack of the dictionary, simple word list and letter distribution.'"`
+	SkipProperNouns bool     `kong:"name='skip-proper-nouns',help='Leave out the words derived from capitalized stems of the dictionary.'"`
+	ExcludeAffixes  []string `kong:"name='exclude-affixes',help='Flags of the affix classes in the affix file not to apply, e.g. S for English plurals.'"`
+}
+
+// expandOptions returns the expansion settings configured by the flags.
+func (f DictionaryFlags) expandOptions() hunspell.ExpandOptions {
+	return hunspell.ExpandOptions{SkipProperNouns: f.SkipProperNouns, ExcludeFlags: f.ExcludeAffixes}
+}
+
+// Dictionaries parses the word lists of a language pack on first use and shares the result
+// between commands.
+type Dictionaries struct {
+	flags    DictionaryFlags
+	lang     *Language
+	forms    func() []hunspell.Form
+	wordList func() []string
+	morph    func() map[string]hunspell.Form
+	words    func() *engine.Trie
+	simple   func() *engine.Trie
+	letters  func() *letterDistribution
+	digests  func() map[string]string
+
+	mu     sync.Mutex
+	others map[string]*Dictionaries
+}
+
+func newDictionaries(flags DictionaryFlags) (*Dictionaries, error) {
+	lang, err := loadLanguage(flags.Lang)
+	if err != nil {
+		return nil, err
+	}
+	d := &Dictionaries{
+		flags:  flags,
+		lang:   lang,
+		simple: sync.OnceValue(func() *engine.Trie { return engine.ParseTrie(lang.simple) }),
+	}
+	d.forms = sync.OnceValue(func() []hunspell.Form {
+		forms, err := expandDictionary(lang.dic, lang.aff, flags.expandOptions())
+		if err != nil {
+			// The dictionary is embedded, so this can only happen if the data files are broken.
+			panic(fmt.Sprintf("expanding the embedded %s dictionary: %v", lang.Code, err))
+		}
+		return forms
+	})
+	d.wordList = sync.OnceValue(func() []string { return formWords(d.forms()) })
+	d.morph = sync.OnceValue(func() map[string]hunspell.Form {
+		morph := make(map[string]hunspell.Form, len(d.forms()))
+		for _, form := range d.forms() {
+			word := engine.NormalizeWord(form.Word)
+			if prev, ok := morph[word]; !ok || len(form.Affixes) < len(prev.Affixes) {
+				morph[word] = form
+			}
+		}
+		return morph
+	})
+	d.words = sync.OnceValue(func() *engine.Trie { return engine.NewTrie(d.wordList()) })
+	d.letters = sync.OnceValue(func() *letterDistribution {
+		if lang.letters == "" {
+			return newLetterDistribution(deriveLetterFrequencies(d.wordList()))
+		}
+		frequencies, err := parseLetterFrequencies(lang.letters)
+		if err != nil {
+			panic(fmt.Sprintf("parsing the embedded %s letter frequencies: %v", lang.Code, err))
+		}
+		return newLetterDistribution(frequencies)
+	})
+	d.digests = sync.OnceValue(func() map[string]string {
+		digests := map[string]string{
+			lang.Code + ".dic": digest(lang.dic),
+			lang.Code + ".aff": digest(lang.aff),
+			"words":            digest(strings.Join(d.wordList(), "\n")),
+			"usable.txt":       digest(lang.simple),
+		}
+		if lang.letters != "" {
+			digests["letters.txt"] = digest(lang.letters)
+		}
+		return digests
+	})
+	return d, nil
+}
+
+// expandDictionary expands the stems of a Hunspell dictionary with its affix rules.
+func expandDictionary(dic, aff string, opts hunspell.ExpandOptions) ([]hunspell.Form, error) {
+	affixes, err := hunspell.ParseAffixes(strings.NewReader(aff))
+	if err != nil {
+		return nil, fmt.Errorf("parsing affixes: %w", err)
+	}
+	entries, err := hunspell.ParseDictionary(strings.NewReader(dic), affixes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing stems: %w", err)
+	}
+	return affixes.ExpandForms(entries, opts), nil
+}
+
+// formWords returns the words of forms.
+func formWords(forms []hunspell.Form) []string {
+	words := make([]string, len(forms))
+	for i, form := range forms {
+		words[i] = form.Word
+	}
+	return words
+}
+
+// Words returns the full dictionary.
+func (d *Dictionaries) Words() *engine.Trie {
+	return d.words()
+}
+
+// WordList returns the words of the full dictionary as expanded, in sorted order.
+func (d *Dictionaries) WordList() []string {
+	return d.wordList()
+}
+
+// Form returns how the dictionary derives word, matched after normalizing it like the tries do.
+func (d *Dictionaries) Form(word string) (hunspell.Form, bool) {
+	form, ok := d.morph()[engine.NormalizeWord(word)]
+	return form, ok
+}
+
+// Stems maps each of words to the stem the dictionary derives it from.
+func (d *Dictionaries) Stems(words engine.FoundWordsSet) map[string]string {
+	stems := make(map[string]string, len(words))
+	for word := range words {
+		if form, ok := d.Form(word); ok {
+			stems[word] = engine.NormalizeWord(form.Stem)
+		}
+	}
+	return stems
+}
+
+// Digests returns the SHA-256 digest of each embedded word list, keyed by file name, and of the
+// expanded dictionary under "words".
+func (d *Dictionaries) Digests() map[string]string {
+	return d.digests()
+}
+
+// ForLanguage returns the dictionaries of the language pack with the given code, expanded with
+// the same flags as d. An empty code stands for the language of d.
+func (d *Dictionaries) ForLanguage(code string) (*Dictionaries, error) {
+	if code == "" || code == d.lang.Code {
+		return d, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if other, ok := d.others[code]; ok {
+		return other, nil
+	}
+	flags := d.flags
+	flags.Lang = code
+	other, err := newDictionaries(flags)
+	if err != nil {
+		return nil, err
+	}
+	if d.others == nil {
+		d.others = make(map[string]*Dictionaries)
+	}
+	d.others[code] = other
+	return other, nil
+}
+
+// Language returns the language pack the dictionaries come from.
+func (d *Dictionaries) Language() *Language {
+	return d.lang
+}
+
+// Letters returns the distribution grid letters are drawn from.
+func (d *Dictionaries) Letters() *letterDistribution {
+	return d.letters()
+}
+
+// Simple returns the list of simple words allowed in puzzles.
+func (d *Dictionaries) Simple() *engine.Trie {
+	return d.simple()
+}
+
+type DefaultableDate struct {
+	Time *time.Time
+}
+
+func (d *DefaultableDate) UnmarshalText(text []byte) error {
+	s := string(text)
+	now := time.Now()
+	defaultDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if s == "" {
+		d.Time = &defaultDate
+		return nil
+	}
+
+	parsedTime, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return fmt.Errorf("invalid date format for '%s': expected YYYY-MM-DD. Error: %w", s, err)
+	}
+	d.Time = &parsedTime
+	return nil
+}
+
+// String returns the date in YYYY-MM-DD format. Useful for printing.
+func (d DefaultableDate) String() string {
+	if d.Time == nil { // Truly zero and not because it was defaulted from empty
+		return "<not set>"
+	}
+	return d.Time.Format("2006-01-02")
+}
+
+func main() {
+	// Commands stop gracefully on SIGINT or SIGTERM; a second signal kills the process.
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-runCtx.Done()
+		stop()
+	}()
+
+	var cli CLI
+	ctx := kong.Parse(&cli,
+		kong.Name("wordseq"),
+		kong.Description("Generate, solve and check wordseq puzzles."),
+		kong.UsageOnError(),
+		kong.BindTo(runCtx, (*context.Context)(nil)),
+	)
+	dicts, err := newDictionaries(cli.DictionaryFlags)
+	ctx.FatalIfErrorf(err)
+	err = ctx.Run(dicts)
+	ctx.FatalIfErrorf(err)
+}
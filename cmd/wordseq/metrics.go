@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// FilterFlags reject generated levels by their quality metrics and the kinds of words they use.
+// Zero values are no limit.
+// Profiles in a config file may set them too, overriding the flags.
+type FilterFlags struct {
+	MinOptimalPaths int64   `kong:"name='min-optimal-paths',help='Minimum number of distinct optimal move sequences.'" yaml:"minOptimalPaths" json:"minOptimalPaths,omitempty"`
+	MaxOptimalPaths int64   `kong:"name='max-optimal-paths',help='Maximum number of distinct optimal move sequences.'" yaml:"maxOptimalPaths" json:"maxOptimalPaths,omitempty"`
+	MinFirstMoves   int     `kong:"name='min-first-moves',help='Minimum number of moves available from the initial grid.'" yaml:"minFirstMoves" json:"minFirstMoves,omitempty"`
+	MaxFirstMoves   int     `kong:"name='max-first-moves',help='Maximum number of moves available from the initial grid.'" yaml:"maxFirstMoves" json:"maxFirstMoves,omitempty"`
+	MaxDeadEndRatio float64 `kong:"name='max-dead-end-ratio',help='Maximum fraction of states, between 0 and 1, from which no move forms a word.'" yaml:"maxDeadEndRatio" json:"maxDeadEndRatio,omitempty"`
+	MinScore        float64 `kong:"name='min-score',help='Minimum difficulty score, between 0 and 1.'" yaml:"minScore" json:"minScore,omitempty"`
+	MaxScore        float64 `kong:"name='max-score',help='Maximum difficulty score, between 0 and 1.'" yaml:"maxScore" json:"maxScore,omitempty"`
+
+	MaxGreedyOptimalRate float64 `kong:"name='max-greedy-optimal-rate',help='Maximum fraction of simulated greedy games that reach the max depth.'" yaml:"maxGreedyOptimalRate" json:"maxGreedyOptimalRate,omitempty"`
+	MinRandomDepth       float64 `kong:"name='min-random-depth',help='Minimum average depth reached by simulated random play.'" yaml:"minRandomDepth" json:"minRandomDepth,omitempty"`
+
+	ExcludePlurals     bool `kong:"name='exclude-plurals',help='Reject levels with words the dictionary derives with a plural suffix, such as sacks from sack.'" yaml:"excludePlurals" json:"excludePlurals,omitempty"`
+	ExcludeInflections bool `kong:"name='exclude-inflections',help='Reject levels with inflected words: plurals and -s, -ed, -ing, -est and possessive forms.'" yaml:"excludeInflections" json:"excludeInflections,omitempty"`
+}
+
+// Accept reports whether a level with the given metrics, difficulty and simulated play passes
+// every filter. A filter on a strategy that was not simulated rejects every level.
+func (f FilterFlags) Accept(m engine.Metrics, c engine.Classification, sims []engine.Simulation) bool {
+	greedy := engine.FindSimulation(sims, "greedy")
+	random := engine.FindSimulation(sims, "random")
+	switch {
+	case f.MinOptimalPaths > 0 && m.OptimalPaths < f.MinOptimalPaths:
+		return false
+	case f.MaxOptimalPaths > 0 && m.OptimalPaths > f.MaxOptimalPaths:
+		return false
+	case f.MinFirstMoves > 0 && m.FirstMoves < f.MinFirstMoves:
+		return false
+	case f.MaxFirstMoves > 0 && m.FirstMoves > f.MaxFirstMoves:
+		return false
+	case f.MaxDeadEndRatio > 0 && m.DeadEndRatio > f.MaxDeadEndRatio:
+		return false
+	case f.MinScore > 0 && c.Score < f.MinScore:
+		return false
+	case f.MaxScore > 0 && c.Score > f.MaxScore:
+		return false
+	case f.MaxGreedyOptimalRate > 0 && (greedy == nil || greedy.OptimalRate > f.MaxGreedyOptimalRate):
+		return false
+	case f.MinRandomDepth > 0 && (random == nil || random.MeanDepth < f.MinRandomDepth):
+		return false
+	}
+	return true
+}
+
+// override returns f with every filter that is set in other replaced by other's value.
+func (f FilterFlags) override(other FilterFlags) FilterFlags {
+	if other.MinOptimalPaths != 0 {
+		f.MinOptimalPaths = other.MinOptimalPaths
+	}
+	if other.MaxOptimalPaths != 0 {
+		f.MaxOptimalPaths = other.MaxOptimalPaths
+	}
+	if other.MinFirstMoves != 0 {
+		f.MinFirstMoves = other.MinFirstMoves
+	}
+	if other.MaxFirstMoves != 0 {
+		f.MaxFirstMoves = other.MaxFirstMoves
+	}
+	if other.MaxDeadEndRatio != 0 {
+		f.MaxDeadEndRatio = other.MaxDeadEndRatio
+	}
+	if other.MinScore != 0 {
+		f.MinScore = other.MinScore
+	}
+	if other.MaxScore != 0 {
+		f.MaxScore = other.MaxScore
+	}
+	if other.MaxGreedyOptimalRate != 0 {
+		f.MaxGreedyOptimalRate = other.MaxGreedyOptimalRate
+	}
+	if other.MinRandomDepth != 0 {
+		f.MinRandomDepth = other.MinRandomDepth
+	}
+	if other.ExcludePlurals {
+		f.ExcludePlurals = true
+	}
+	if other.ExcludeInflections {
+		f.ExcludeInflections = true
+	}
+	return f
+}
+
+// printMetrics prints the quality metrics of a level in the style of the level summaries.
+func printMetrics(w io.Writer, m engine.Metrics) {
+	branching := make([]string, len(m.BranchingFactor))
+	for i, b := range m.BranchingFactor {
+		branching[i] = fmt.Sprintf("%.1f", b)
+	}
+	fmt.Fprintf(w, "  Optimal Paths:            %d\n", m.OptimalPaths)
+	fmt.Fprintf(w, "  Branching Per Depth:      %s\n", strings.Join(branching, " "))
+	fmt.Fprintf(w, "  Dead-End Ratio:           %.2f\n", m.DeadEndRatio)
+	fmt.Fprintf(w, "  Trap Ratio:               %.2f\n", m.TrapRatio)
+	fmt.Fprintf(w, "  Total Nodes:              %d\n", m.TotalNodes)
+}
+
+// printClassification prints the difficulty score of a level and the features behind it.
+func printClassification(w io.Writer, c engine.Classification) {
+	fmt.Fprintf(w, "  Difficulty Score:         %.3f (depth %.2f, scarcity %.2f, traps %.2f, rarity %.2f)\n",
+		c.Score, c.Depth, c.Scarcity, c.Traps, c.Rarity)
+}
+
+// checkStrategies returns an error if a filter depends on a strategy that is not simulated.
+func (f FilterFlags) checkStrategies(strategies []engine.Strategy) error {
+	simulated := func(name string) bool {
+		return slices.ContainsFunc(strategies, func(s engine.Strategy) bool { return s.Name() == name })
+	}
+	switch {
+	case f.MaxGreedyOptimalRate > 0 && !simulated("greedy"):
+		return errors.New("--max-greedy-optimal-rate needs greedy in --sim-strategies")
+	case f.MinRandomDepth > 0 && !simulated("random"):
+		return errors.New("--min-random-depth needs random in --sim-strategies")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// MigrateCmd upgrades level files written with an older schema version in place.
+type MigrateCmd struct {
+	Paths  []string `kong:"arg,name='path',type='existingfile|existingdir',help='Level files or directories of level files to migrate.'"`
+	DryRun bool     `kong:"name='dry-run',help='Report the files that need migrating without rewriting them.'"`
+}
+
+// Run migrates every level file under cmd.Paths to engine.SchemaVersion, keeping its layout.
+func (cmd *MigrateCmd) Run() error {
+	checked, migrated := 0, 0
+	for _, root := range cmd.Paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(path) != ".json" {
+				return nil
+			}
+			checked++
+			changed, err := cmd.migrateLevelFile(path)
+			if err != nil {
+				return err
+			}
+			if changed {
+				migrated++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	verb := "Migrated"
+	if cmd.DryRun {
+		verb = "Would migrate"
+	}
+	fmt.Printf("%s %d of %d level files to schema version %d.\n", verb, migrated, checked, engine.SchemaVersion)
+	return nil
+}
+
+// migrateLevelFile rewrites path at the current schema version if it is older, and reports
+// whether it needed migrating.
+func (cmd *MigrateCmd) migrateLevelFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	header, err := engine.ReadLevelHeader(data)
+	if err != nil {
+		return false, fmt.Errorf("parsing '%s': %w", path, err)
+	}
+	if header.SchemaVersion == engine.SchemaVersion {
+		return false, nil
+	}
+	level, err := engine.ParseLevel(data)
+	if err != nil {
+		return false, fmt.Errorf("parsing '%s': %w", path, err)
+	}
+	if cmd.DryRun {
+		fmt.Printf("%s: schema version %d\n", path, header.SchemaVersion)
+		return true, nil
+	}
+
+	format := formatTree
+	if header.Format == engine.DagFormat {
+		format = formatDag
+	}
+	jsonData, err := marshalLevel(level, format)
+	if err != nil {
+		return false, fmt.Errorf("marshaling '%s': %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(path, jsonData, info.Mode().Perm()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+func TestMigrateLevelFile(t *testing.T) {
+	data, err := os.ReadFile("../../engine/testdata/level_v1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "01.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &MigrateCmd{DryRun: true}
+	if changed, err := cmd.migrateLevelFile(path); err != nil || !changed {
+		t.Fatalf("dry run: migrateLevelFile = %v, %v, want true", changed, err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(data) {
+		t.Error("a dry run rewrote the file")
+	}
+
+	cmd.DryRun = false
+	if changed, err := cmd.migrateLevelFile(path); err != nil || !changed {
+		t.Fatalf("migrateLevelFile = %v, %v, want true", changed, err)
+	}
+	migrated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := engine.ReadLevelHeader(migrated)
+	if err != nil || header.SchemaVersion != engine.SchemaVersion {
+		t.Errorf("migrated header = %+v, %v, want version %d", header, err, engine.SchemaVersion)
+	}
+	level, err := engine.ParseLevel(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := level.Provenance; p == nil || p.Seed != 8935141660703064064 || p.Difficulty != "hard" {
+		t.Errorf("provenance = %+v, want the seed and difficulty of the v1 file", p)
+	}
+
+	if changed, err := cmd.migrateLevelFile(path); err != nil || changed {
+		t.Errorf("second migration: migrateLevelFile = %v, %v, want false", changed, err)
+	}
+}
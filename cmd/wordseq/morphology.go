@@ -0,0 +1,29 @@
+package main
+
+import "github.com/sudorandom/wordchain/engine"
+
+// excludedAffixes returns the affix classes of the language whose words the filters reject.
+func (f FilterFlags) excludedAffixes(lang *Language) []string {
+	switch {
+	case f.ExcludeInflections:
+		return lang.InflectionAffixes
+	case f.ExcludePlurals:
+		return lang.PluralAffixes
+	}
+	return nil
+}
+
+// derivedWith returns the words the dictionary derives with any of the affix classes named by
+// flags. Words that are also stems of their own, such as news, are never included.
+func (d *Dictionaries) derivedWith(words engine.FoundWordsSet, flags []string) []string {
+	if len(flags) == 0 {
+		return nil
+	}
+	var derived []string
+	for word := range words {
+		if form, ok := d.Form(word); ok && form.Derived(flags) {
+			derived = append(derived, word)
+		}
+	}
+	return derived
+}
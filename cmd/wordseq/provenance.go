@@ -0,0 +1,58 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// generatorInfo describes this build, from the module and VCS information stamped in by go build.
+var generatorInfo = sync.OnceValue(func() *engine.BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	build := &engine.BuildInfo{
+		Path:      info.Main.Path,
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+})
+
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// newProvenance records the current build and word lists along with the given settings.
+func newProvenance(dicts *Dictionaries, config any, seed uint64, difficulty string) (*engine.Provenance, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	return &engine.Provenance{
+		Generator:    generatorInfo(),
+		GeneratedAt:  &now,
+		Dictionaries: dicts.Digests(),
+		Config:       configJSON,
+		Seed:         seed,
+		Difficulty:   difficulty,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// Ways of looking for grids, as chosen by --search.
+const (
+	// searchRandom draws a fresh random grid for every attempt.
+	searchRandom = "random"
+	// searchClimb keeps mutating a grid as long as the mutations score no worse.
+	searchClimb = "climb"
+	// searchAnneal is searchClimb that also keeps worse mutations, less and less often as the
+	// search on one grid goes on, to get out of dead ends.
+	searchAnneal = "anneal"
+)
+
+// annealCooling is the factor the annealing temperature is multiplied by after every mutation.
+const annealCooling = 0.98
+
+// searchGrid looks for an acceptable grid by mutating grids toward the requirements of cmd.
+// Grids are scored by a shallow exploration that stops at RequiredMinTurns, which is much
+// cheaper than a full one; grids that reach that depth are explored in full and the first one
+// accepted is returned. After SearchSteps mutations the search starts over from a fresh grid.
+func (cmd *GenerateCmd) searchGrid(
+	ctx context.Context,
+	rng *rand.Rand,
+	rules engine.Rules,
+	wordMap engine.Dictionary,
+	simpleWordMap engine.Dictionary,
+	gridAttemptsTotal *int64,
+	gridAbortsTotal *int64,
+) (WorkerResult, error) {
+	probeRules := rules
+	probeRules.MaxTurns = min(rules.MaxTurns, cmd.RequiredMinTurns)
+
+	var current engine.Grid
+	var currentScore, temperature float64
+	steps := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return WorkerResult{}, context.Cause(ctx)
+		}
+
+		var grid engine.Grid
+		if current == nil || steps >= cmd.SearchSteps {
+			grid, current, steps = generateGrid(rng, cmd.dicts.Letters(), cmd.GridRows, cmd.GridCols), nil, 0
+			if cmd.Search == searchAnneal {
+				temperature = 1
+			}
+		} else {
+			grid = mutateGrid(rng, cmd.dicts.Letters(), current)
+			steps++
+			temperature *= annealCooling
+		}
+		if grid == nil {
+			return WorkerResult{}, errors.New("grid dimensions must be positive")
+		}
+
+		atomic.AddInt64(gridAttemptsTotal, 1)
+		score, depth, ok, err := cmd.probe(ctx, probeRules, grid, wordMap, simpleWordMap)
+		if errors.Is(err, engine.ErrBudgetExceeded) {
+			atomic.AddInt64(gridAbortsTotal, 1)
+			continue
+		} else if err != nil {
+			return WorkerResult{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		if depth >= cmd.RequiredMinTurns {
+			result, ok, err := cmd.evaluate(ctx, rules, grid, wordMap, simpleWordMap)
+			if errors.Is(err, engine.ErrBudgetExceeded) {
+				atomic.AddInt64(gridAbortsTotal, 1)
+				continue
+			} else if err != nil {
+				return WorkerResult{}, err
+			}
+			if ok && cmd.accepts(result) {
+				return result, nil
+			}
+			// Deep enough but rejected by the word limits or filters: worth keeping as a starting
+			// point, but no better than the grids just short of the depth.
+			score--
+		}
+
+		delta := score - currentScore
+		if current == nil || delta >= 0 || (temperature > 0 && rng.Float64() < math.Exp(delta/temperature)) {
+			current, currentScore = grid, score
+		}
+	}
+}
+
+// probe scores a grid by exploring it under rules, which stop short of the full turn limit.
+// Every turn reached counts one point and more first moves count for a little, while each word
+// outside the simple word list or rejected by the morphology filters and each word beyond
+// MaxUniqueWords costs a fraction of a turn. It returns false for grids that spell a word
+// before any move, which are never valid.
+func (cmd *GenerateCmd) probe(ctx context.Context, rules engine.Rules, grid engine.Grid, wordMap, simpleWordMap engine.Dictionary) (float64, int, bool, error) {
+	if len(engine.FindAllWords(rules, grid, wordMap)) > 0 {
+		return 0, 0, false, nil
+	}
+	explorationTree, maxDepth, err := engine.SolveContext(ctx, rules, grid, wordMap, engine.SolveOptions{Budget: cmd.Budget()})
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	wordSet := make(engine.FoundWordsSet)
+	engine.CollectAllWords(explorationTree, wordSet)
+	unusual := len(cmd.dicts.derivedWith(wordSet, cmd.excludedAffixes(cmd.dicts.Language())))
+	for word := range wordSet {
+		if !simpleWordMap.Contains(word) {
+			unusual++
+		}
+	}
+	excess := max(0, len(wordSet)-cmd.MaxUniqueWords)
+	firstMoves := float64(len(explorationTree))
+	score := float64(maxDepth) + firstMoves/(firstMoves+10) - 0.5*float64(unusual) - 0.25*float64(excess)
+	return score, maxDepth, true, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// ServeCmd serves the level files and an on-demand solver over HTTP.
+// The rules flags are the solver defaults; --max-turns also caps what a request may ask for.
+// Unlike the other commands the budget is limited by default, since anyone can send a grid.
+type ServeCmd struct {
+	RulesFlags  `kong:"embed"`
+	BudgetFlags `kong:"embed,set='maxNodes=5000000',set='maxCacheEntries=1000000',set='gridTimeout=10s'"`
+
+	Addr     string `kong:"name='addr',default=':8080',help='Address to listen on.'"`
+	Levels   string `kong:"name='levels',default='frontend/public/levels',help='Level directory served under /levels/.'"`
+	MaxCells int    `kong:"name='max-cells',default='36',help='Reject grids with more cells than this. 0 means no limit.'"`
+}
+
+// Run listens on cmd.Addr until the server fails or ctx is cancelled.
+func (cmd *ServeCmd) Run(ctx context.Context, dicts *Dictionaries) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /levels/", http.StripPrefix("/levels/", http.FileServer(http.Dir(cmd.Levels))))
+	mux.HandleFunc("GET /api/solve", func(w http.ResponseWriter, r *http.Request) {
+		cmd.handleSolve(w, r, dicts)
+	})
+
+	server := &http.Server{Addr: cmd.Addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			fmt.Printf("Error shutting down server: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("Serving %s under /levels/ and the solver under /api/solve on %s\n", cmd.Levels, cmd.Addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleSolve explores the grid given in the query string and responds with the level JSON.
+// Query parameters: grid (rows separated by slashes), wordLength and maxTurns.
+func (cmd *ServeCmd) handleSolve(w http.ResponseWriter, r *http.Request, dicts *Dictionaries) {
+	query := r.URL.Query()
+	grid, err := engine.ParseGrid(query.Get("grid"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if cells := len(grid) * len(grid[0]); cmd.MaxCells > 0 && cells > cmd.MaxCells {
+		http.Error(w, fmt.Sprintf("grid has %d cells, at most %d are allowed", cells, cmd.MaxCells), http.StatusBadRequest)
+		return
+	}
+	rules := cmd.Rules()
+	if v := query.Get("wordLength"); v != "" {
+		if rules.WordLength, err = strconv.Atoi(v); err != nil || rules.WordLength <= 0 {
+			http.Error(w, "invalid wordLength", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("maxTurns"); v != "" {
+		if rules.MaxTurns, err = strconv.Atoi(v); err != nil || rules.MaxTurns <= 0 || rules.MaxTurns > cmd.RequiredMaxTurns {
+			http.Error(w, fmt.Sprintf("maxTurns must be between 1 and %d", cmd.RequiredMaxTurns), http.StatusBadRequest)
+			return
+		}
+	}
+
+	explorationTree, maxDepth, err := engine.SolveContext(r.Context(), rules, grid, dicts.Words(), engine.SolveOptions{Workers: runtime.NumCPU(), Budget: cmd.Budget()})
+	if errors.Is(err, engine.ErrBudgetExceeded) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	metrics := engine.ComputeMetrics(explorationTree, maxDepth, rules.MaxTurns)
+	wordSet := make(engine.FoundWordsSet)
+	engine.CollectAllWords(explorationTree, wordSet)
+	classification := engine.Classify(metrics, maxDepth, rules.MaxTurns, wordSet, dicts.Letters().rarity, engine.DefaultDifficultyWeights)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(engine.FullExplorationOutput{
+		LevelInfo: engine.LevelInfo{
+			SchemaVersion:    engine.SchemaVersion,
+			Language:         dicts.Language().Code,
+			InitialGrid:      engine.ConvertGridToJsonGrid(grid),
+			WordLength:       rules.WordLength,
+			RequiredMaxTurns: rules.MaxTurns,
+			MaxDepthReached:  maxDepth,
+			Metrics:          &metrics,
+			Classification:   &classification,
+			Stems:            dicts.Stems(wordSet),
+		},
+		ExplorationTree: explorationTree,
+	}); err != nil {
+		fmt.Printf("Error writing solve response: %v\n", err)
+	}
+}
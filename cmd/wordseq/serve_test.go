@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+func TestBudgetFlagDefaults(t *testing.T) {
+	tests := []struct {
+		args   []string
+		budget func(cli *CLI) engine.Budget
+		want   engine.Budget
+	}{
+		{
+			args:   []string{"serve"},
+			budget: func(cli *CLI) engine.Budget { return cli.Serve.Budget() },
+			want:   engine.Budget{MaxNodes: 5000000, MaxCacheEntries: 1000000, Timeout: 10 * time.Second},
+		},
+		{
+			args:   []string{"serve", "--max-nodes=0", "--grid-timeout=1m"},
+			budget: func(cli *CLI) engine.Budget { return cli.Serve.Budget() },
+			want:   engine.Budget{MaxCacheEntries: 1000000, Timeout: time.Minute},
+		},
+		{
+			args:   []string{"generate"},
+			budget: func(cli *CLI) engine.Budget { return cli.Generate.Budget() },
+		},
+	}
+	for _, test := range tests {
+		var cli CLI
+		parser, err := kong.New(&cli, kong.Exit(func(int) { t.Fatalf("%v: parser exited", test.args) }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parser.Parse(test.args); err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+		if got := test.budget(&cli); got != test.want {
+			t.Errorf("%v: budget %+v, want %+v", test.args, got, test.want)
+		}
+	}
+}
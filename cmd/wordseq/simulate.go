@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// SimulationFlags choose how levels are played by simulated players.
+type SimulationFlags struct {
+	Strategies []string `kong:"name='sim-strategies',default='random,greedy,lookahead-2',help='Strategies of the simulated players: random, greedy (most words now) or lookahead-N.'"`
+	SimGames   int      `kong:"name='sim-games',default='200',help='Number of games each simulated player plays per level.'"`
+}
+
+// strategies parses the configured strategies.
+func (f SimulationFlags) strategies() ([]engine.Strategy, error) {
+	strategies := make([]engine.Strategy, len(f.Strategies))
+	for i, name := range f.Strategies {
+		strategy, err := engine.ParseStrategy(name)
+		if err != nil {
+			return nil, fmt.Errorf("--sim-strategies: %w", err)
+		}
+		strategies[i] = strategy
+	}
+	return strategies, nil
+}
+
+// simulate plays a level with every strategy. Each strategy draws from its own source, seeded
+// from the grid, so the results do not depend on which strategies run alongside it.
+func (f SimulationFlags) simulate(strategies []engine.Strategy, grid engine.Grid, explorationTree []engine.ExplorationNode, maxDepth int) []engine.Simulation {
+	sims := make([]engine.Simulation, len(strategies))
+	for i, strategy := range strategies {
+		h := fnv.New64a()
+		for _, row := range grid {
+			fmt.Fprintf(h, "%s/", string(row))
+		}
+		fmt.Fprint(h, strategy.Name())
+		rng := rand.New(rand.NewPCG(h.Sum64(), 0))
+		sims[i] = engine.Simulate(explorationTree, maxDepth, strategy, f.SimGames, rng)
+	}
+	return sims
+}
+
+// printSimulations prints the outcome of simulated play in the style of the level summaries.
+func printSimulations(w io.Writer, sims []engine.Simulation) {
+	if len(sims) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "  Simulated Play:")
+	for _, sim := range sims {
+		depths := make([]string, len(sim.Depths))
+		for d, n := range sim.Depths {
+			depths[d] = strconv.Itoa(n)
+		}
+		fmt.Fprintf(w, "    %-14s  mean depth %.1f, optimal %3.0f%%, games by depth %s\n",
+			sim.Strategy, sim.MeanDepth, 100*sim.OptimalRate, strings.Join(depths, " "))
+	}
+}
+
+// SimulateCmd plays stored levels with simulated players.
+type SimulateCmd struct {
+	SimulationFlags `kong:"embed"`
+
+	Dir string `kong:"arg,name='dir',help='Level directory, or single level file, to play.'"`
+}
+
+// Run plays every level under cmd.Dir and prints how far each strategy gets.
+func (cmd *SimulateCmd) Run() error {
+	strategies, err := cmd.strategies()
+	if err != nil {
+		return err
+	}
+	levels := 0
+	totals := make([]engine.Simulation, len(strategies))
+	err = walkLevels(cmd.Dir, func(path string, level *engine.FullExplorationOutput) error {
+		levels++
+		grid, err := engine.ConvertJsonGridToGrid(level.InitialGrid)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		sims := cmd.simulate(strategies, grid, level.ExplorationTree, level.MaxDepthReached)
+		fmt.Printf("%s (max depth %d)\n", path, level.MaxDepthReached)
+		printSimulations(os.Stdout, sims)
+		for i, sim := range sims {
+			totals[i].MeanDepth += sim.MeanDepth
+			totals[i].OptimalRate += sim.OptimalRate
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if levels == 0 {
+		fmt.Printf("No levels found in %s.\n", cmd.Dir)
+		return nil
+	}
+
+	fmt.Printf("--- Simulated Play: %d levels, %d games per strategy ---\n", levels, cmd.SimGames)
+	for i, strategy := range strategies {
+		fmt.Printf("  %-14s  mean depth %.1f, optimal %3.0f%%\n",
+			strategy.Name(), totals[i].MeanDepth/float64(levels), 100*totals[i].OptimalRate/float64(levels))
+	}
+	fmt.Println("---------------------------")
+	return nil
+}
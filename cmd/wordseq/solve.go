@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// SolveCmd explores a single grid, such as a hand-made or tweaked one.
+type SolveCmd struct {
+	RulesFlags      `kong:"embed"`
+	BudgetFlags     `kong:"embed"`
+	SimulationFlags `kong:"embed"`
+
+	Grid             string `kong:"arg,optional,name='grid',help='Grid rows separated by slashes, e.g. sact/tnek/onhw.'"`
+	File             string `kong:"name='file',short='f',type='existingfile',help='Read the grid from a JSON file holding either an initialGrid array or a level with an initialGrid field.'"`
+	RequiredMinTurns int    `kong:"name='min-turns',short='t',default='7',help='Minimum number of turns required for a solvable puzzle.'"`
+	Output           string `kong:"name='output',short='o',help='Write the level JSON to this file, or to stdout with -. The summary goes to stderr when the JSON goes to stdout.'"`
+	Format           string `kong:"name='format',enum='tree,dag',default='tree',help='Layout of the level JSON.'"`
+	Jobs             int    `kong:"name='jobs',short='j',help='Number of goroutines exploring the grid. Defaults to the number of CPUs.'"`
+}
+
+// Run explores the grid, writes the level JSON if requested and prints a summary of the result.
+func (cmd *SolveCmd) Run(ctx context.Context, dicts *Dictionaries) error {
+	grid, err := cmd.loadGrid()
+	if err != nil {
+		return err
+	}
+	strategies, err := cmd.strategies()
+	if err != nil {
+		return err
+	}
+	rules := cmd.Rules()
+	jobs := cmd.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	explorationTree, maxDepth, err := engine.SolveContext(ctx, rules, grid, dicts.Words(), engine.SolveOptions{Workers: jobs, Budget: cmd.Budget()})
+	if err != nil {
+		return fmt.Errorf("exploring grid: %w", err)
+	}
+	metrics := engine.ComputeMetrics(explorationTree, maxDepth, rules.MaxTurns)
+	wordSet := make(engine.FoundWordsSet)
+	engine.CollectAllWords(explorationTree, wordSet)
+	classification := engine.Classify(metrics, maxDepth, rules.MaxTurns, wordSet, dicts.Letters().rarity, engine.DefaultDifficultyWeights)
+	sims := cmd.simulate(strategies, grid, explorationTree, maxDepth)
+
+	summary := io.Writer(os.Stdout)
+	if cmd.Output != "" {
+		provenance, err := newProvenance(dicts, map[string]any{
+			"command":    "solve",
+			"wordLength": rules.WordLength,
+			"minTurns":   cmd.RequiredMinTurns,
+			"maxTurns":   rules.MaxTurns,
+			"format":     cmd.Format,
+		}, 0, "")
+		if err != nil {
+			return fmt.Errorf("recording provenance: %w", err)
+		}
+		outputData := engine.FullExplorationOutput{
+			LevelInfo: engine.LevelInfo{
+				SchemaVersion:    engine.SchemaVersion,
+				Language:         dicts.Language().Code,
+				InitialGrid:      engine.ConvertGridToJsonGrid(grid),
+				WordLength:       rules.WordLength,
+				RequiredMinTurns: cmd.RequiredMinTurns,
+				RequiredMaxTurns: rules.MaxTurns,
+				MaxDepthReached:  maxDepth,
+				Metrics:          &metrics,
+				Classification:   &classification,
+				Simulations:      sims,
+				Stems:            dicts.Stems(wordSet),
+				Provenance:       provenance,
+			},
+			ExplorationTree: explorationTree,
+		}
+		jsonData, err := marshalLevel(&outputData, cmd.Format)
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %w", err)
+		}
+		if cmd.Output == "-" {
+			summary = os.Stderr
+			if _, err := os.Stdout.Write(append(jsonData, '\n')); err != nil {
+				return err
+			}
+		} else if err := os.WriteFile(cmd.Output, jsonData, 0644); err != nil {
+			return fmt.Errorf("writing JSON to file '%s': %w", cmd.Output, err)
+		}
+	}
+
+	printSolveSummary(summary, grid, explorationTree, maxDepth, metrics, classification, sims, dicts.Simple())
+	if maxDepth < cmd.RequiredMinTurns {
+		fmt.Fprintf(summary, "Grid reaches depth %d, below the required minimum of %d turns.\n", maxDepth, cmd.RequiredMinTurns)
+	}
+	if initialWords := engine.FindAllWords(rules, grid, dicts.Words()); len(initialWords) > 0 {
+		fmt.Fprintf(summary, "Grid already contains words before any move: %s\n", strings.Join(initialWords, ", "))
+	}
+	return nil
+}
+
+// loadGrid returns the grid given on the command line or in the grid file.
+func (cmd *SolveCmd) loadGrid() (engine.Grid, error) {
+	switch {
+	case cmd.Grid != "" && cmd.File != "":
+		return nil, errors.New("give either a grid or --file, not both")
+	case cmd.Grid != "":
+		return engine.ParseGrid(cmd.Grid)
+	case cmd.File != "":
+		return readGridFile(cmd.File)
+	default:
+		return nil, errors.New("a grid or --file is required")
+	}
+}
+
+// readGridFile reads a grid from a JSON file in the initialGrid shape, either bare or as
+// the initialGrid field of a level.
+func readGridFile(path string) (engine.Grid, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var jsonGrid engine.JsonGrid
+	if err := json.Unmarshal(data, &jsonGrid); err != nil {
+		var level engine.FullExplorationOutput
+		if levelErr := json.Unmarshal(data, &level); levelErr != nil {
+			return nil, fmt.Errorf("parsing '%s': %w", path, err)
+		}
+		jsonGrid = level.InitialGrid
+	}
+	cells, err := engine.ConvertJsonGridToGrid(jsonGrid)
+	if err != nil {
+		return nil, fmt.Errorf("parsing '%s': %w", path, err)
+	}
+	rows := make([]string, len(cells))
+	for r, row := range cells {
+		rows[r] = string(row)
+	}
+	grid, err := engine.ParseGrid(strings.Join(rows, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing '%s': %w", path, err)
+	}
+	return grid, nil
+}
+
+// printSolveSummary prints a human-readable description of an explored grid.
+func printSolveSummary(w io.Writer, grid engine.Grid, explorationTree []engine.ExplorationNode, maxDepth int, metrics engine.Metrics, classification engine.Classification, sims []engine.Simulation, simpleWordMap engine.Dictionary) {
+	allWordsSet := make(engine.FoundWordsSet)
+	engine.CollectAllWords(explorationTree, allWordsSet)
+	allWordsList := make([]string, 0, len(allWordsSet))
+	var unusualWords []string
+	for word := range allWordsSet {
+		allWordsList = append(allWordsList, word)
+		if !simpleWordMap.Contains(word) {
+			unusualWords = append(unusualWords, word)
+		}
+	}
+	sort.Strings(allWordsList)
+	sort.Strings(unusualWords)
+
+	printGrid(w, grid)
+	fmt.Fprintf(w, "  Grid Dimensions:          %d x %d\n", len(grid), len(grid[0]))
+	fmt.Fprintf(w, "  Actual Max Depth Reached: %d\n", maxDepth)
+	fmt.Fprintf(w, "  Possible First Moves:     %d\n", len(explorationTree))
+	fmt.Fprintf(w, "  Total Unique Words Found: %d\n", len(allWordsList))
+	if len(allWordsList) > 0 {
+		fmt.Fprintf(w, "  Words Found:              %s\n", strings.Join(allWordsList, ", "))
+	}
+	if len(unusualWords) > 0 {
+		fmt.Fprintf(w, "  Not In Simple Word List:  %s\n", strings.Join(unusualWords, ", "))
+	}
+	printMetrics(w, metrics)
+	printClassification(w, classification)
+	printSimulations(w, sims)
+	if path := optimalPath(explorationTree); len(path) > 0 {
+		fmt.Fprintln(w, "  Optimal Path:")
+		for i, node := range path {
+			fmt.Fprintf(w, "    %2d. %s -> %s\n", i+1, moveKey(node.Move), strings.Join(node.WordsFormed, ", "))
+		}
+	}
+	fmt.Fprintln(w, "---------------------------")
+}
+
+// optimalPath follows the first deepest move at every level of the exploration tree.
+func optimalPath(nodes []engine.ExplorationNode) []engine.ExplorationNode {
+	var path []engine.ExplorationNode
+	for len(nodes) > 0 {
+		best := 0
+		for i := range nodes {
+			if nodes[i].MaxDepthReached > nodes[best].MaxDepthReached {
+				best = i
+			}
+		}
+		path = append(path, nodes[best])
+		nodes = nodes[best].NextMoves
+	}
+	return path
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// StatsCmd summarizes the levels stored in a directory.
+type StatsCmd struct {
+	Dir string `kong:"arg,name='dir',help='Level directory to summarize, e.g. frontend/public/levels/en/normal.'"`
+}
+
+// Run prints counts, the date range and depth and word distributions for cmd.Dir.
+func (cmd *StatsCmd) Run() error {
+	levels := 0
+	gridSizes := make(map[string]int)
+	depths := make(map[int]int)
+	wordCounts := make([]int, 0)
+	wordUsage := make(map[string]int)
+	dates := make(map[string]struct{})
+	var scores []float64
+	var firstDate, lastDate time.Time
+
+	err := walkLevels(cmd.Dir, func(path string, level *engine.FullExplorationOutput) error {
+		levels++
+		if len(level.InitialGrid) > 0 {
+			gridSizes[fmt.Sprintf("%dx%d, word length %d", len(level.InitialGrid), len(level.InitialGrid[0]), level.WordLength)]++
+		}
+		depths[level.MaxDepthReached]++
+		if level.Classification != nil {
+			scores = append(scores, level.Classification.Score)
+		}
+
+		wordSet := make(engine.FoundWordsSet)
+		engine.CollectAllWords(level.ExplorationTree, wordSet)
+		wordCounts = append(wordCounts, len(wordSet))
+		for word := range wordSet {
+			wordUsage[word]++
+		}
+
+		if date, ok := levelDate(cmd.Dir, path); ok {
+			dates[date.Format("2006-01-02")] = struct{}{}
+			if firstDate.IsZero() || date.Before(firstDate) {
+				firstDate = date
+			}
+			if date.After(lastDate) {
+				lastDate = date
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if levels == 0 {
+		fmt.Printf("No levels found in %s.\n", cmd.Dir)
+		return nil
+	}
+
+	fmt.Printf("--- Level Stats: %s ---\n", cmd.Dir)
+	fmt.Printf("  Levels:                   %d\n", levels)
+	if !firstDate.IsZero() {
+		missing := 0
+		for d := firstDate; !d.After(lastDate); d = d.AddDate(0, 0, 1) {
+			if _, ok := dates[d.Format("2006-01-02")]; !ok {
+				missing++
+			}
+		}
+		fmt.Printf("  Date Range:               %s to %s (%d missing days)\n",
+			firstDate.Format("2006-01-02"), lastDate.Format("2006-01-02"), missing)
+	}
+	fmt.Println("  Grid Sizes:")
+	for _, size := range sortedKeys(gridSizes) {
+		fmt.Printf("    %-22s  %d\n", size, gridSizes[size])
+	}
+	fmt.Println("  Max Depth Reached:")
+	for _, depth := range sortedKeys(depths) {
+		fmt.Printf("    %-22d  %d\n", depth, depths[depth])
+	}
+	sort.Ints(wordCounts)
+	total := 0
+	for _, n := range wordCounts {
+		total += n
+	}
+	fmt.Printf("  Unique Words Per Level:   min %d, avg %.1f, max %d\n",
+		wordCounts[0], float64(total)/float64(len(wordCounts)), wordCounts[len(wordCounts)-1])
+
+	if len(scores) > 0 {
+		sort.Float64s(scores)
+		var sum float64
+		for _, score := range scores {
+			sum += score
+		}
+		fmt.Printf("  Difficulty Score:         min %.3f, avg %.3f, max %.3f (%d levels scored)\n",
+			scores[0], sum/float64(len(scores)), scores[len(scores)-1], len(scores))
+	}
+
+	words := sortedKeys(wordUsage)
+	sort.SliceStable(words, func(i, j int) bool { return wordUsage[words[i]] > wordUsage[words[j]] })
+	fmt.Printf("  Distinct Words:           %d\n", len(words))
+	fmt.Println("  Most Used Words:")
+	for _, word := range words[:min(10, len(words))] {
+		fmt.Printf("    %-22s  %d\n", word, wordUsage[word])
+	}
+	fmt.Println("---------------------------")
+	return nil
+}
+
+func sortedKeys[K string | int, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
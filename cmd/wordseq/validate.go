@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sudorandom/wordchain/engine"
+)
+
+// ValidateCmd checks stored level files.
+type ValidateCmd struct {
+	Dir      string `kong:"arg,name='dir',default='frontend/public/levels',help='Level directory to check.'"`
+	Uncached bool   `kong:"name='uncached',help='Also re-derive every level without the exploration cache and report differences. This is slow.'"`
+}
+
+// Run validates every level under cmd.Dir and fails if any is invalid.
+func (cmd *ValidateCmd) Run(dicts *Dictionaries) error {
+	fmt.Printf("Validating levels in %s...\n", cmd.Dir)
+	invalid, err := validateLevels(cmd.Dir, dicts, engine.DefaultLevelRules)
+	if err != nil {
+		return fmt.Errorf("validating levels: %w", err)
+	}
+	mismatched := 0
+	if cmd.Uncached {
+		fmt.Printf("Verifying levels in %s...\n", cmd.Dir)
+		mismatched, err = verifyLevels(cmd.Dir, dicts)
+		if err != nil {
+			return fmt.Errorf("verifying levels: %w", err)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid levels", invalid)
+	}
+	if mismatched > 0 {
+		return fmt.Errorf("%d levels differ from an uncached exploration", mismatched)
+	}
+	return nil
+}
+
+// verifyLevels re-derives every level file under dir without the exploration cache and
+// reports each difference from the stored tree. It returns the number of levels that differ.
+func verifyLevels(dir string, dicts *Dictionaries) (int, error) {
+	mismatched := 0
+	checked := 0
+	err := walkLevels(dir, func(path string, level *engine.FullExplorationOutput) error {
+		levelDicts, err := dicts.ForLanguage(level.Language)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		dict := levelDicts.Words()
+		rules := engine.Rules{WordLength: level.WordLength, MaxTurns: level.RequiredMaxTurns}
+		grid, err := engine.ConvertJsonGridToGrid(level.InitialGrid)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		tree, maxDepth := engine.SolveUncached(rules, grid, dict)
+
+		var diffs []string
+		if maxDepth != level.MaxDepthReached {
+			diffs = append(diffs, fmt.Sprintf("maxDepthReached: stored %d, derived %d", level.MaxDepthReached, maxDepth))
+		}
+		diffs = append(diffs, diffExplorationTrees("root", level.ExplorationTree, tree)...)
+		checked++
+		if len(diffs) == 0 {
+			return nil
+		}
+		mismatched++
+		fmt.Printf("MISMATCH %s\n", path)
+		for _, diff := range diffs {
+			fmt.Printf("  %s\n", diff)
+		}
+		return nil
+	})
+	fmt.Printf("Verified %d levels, %d differ from an uncached exploration.\n", checked, mismatched)
+	return mismatched, err
+}
+
+// validateLevels checks every level file under dir against the given rules and reports each
+// violation. It returns the number of invalid levels.
+func validateLevels(dir string, dicts *Dictionaries, levelRules []engine.LevelRule) (int, error) {
+	invalid := 0
+	checked := 0
+	err := walkLevels(dir, func(path string, level *engine.FullExplorationOutput) error {
+		levelDicts, err := dicts.ForLanguage(level.Language)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		dict := levelDicts.Words()
+		checked++
+		errs := engine.ValidateLevel(level, dict, levelRules...)
+		if len(errs) == 0 {
+			return nil
+		}
+		invalid++
+		fmt.Printf("INVALID %s\n", path)
+		for _, err := range errs {
+			fmt.Printf("  %v\n", err)
+		}
+		return nil
+	})
+	fmt.Printf("Validated %d levels, %d invalid.\n", checked, invalid)
+	return invalid, err
+}
+
+// diffExplorationTrees compares two exploration trees node by node and describes every
+// difference, identifying nodes by the sequence of moves leading to them.
+func diffExplorationTrees(path string, stored, derived []engine.ExplorationNode) []string {
+	var diffs []string
+	storedByMove := make(map[string]engine.ExplorationNode, len(stored))
+	for _, node := range stored {
+		storedByMove[moveKey(node.Move)] = node
+	}
+	derivedByMove := make(map[string]engine.ExplorationNode, len(derived))
+	for _, node := range derived {
+		derivedByMove[moveKey(node.Move)] = node
+	}
+
+	for _, node := range stored {
+		key := moveKey(node.Move)
+		nodePath := path + " > " + key
+		other, ok := derivedByMove[key]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: stored move is not playable", nodePath))
+			continue
+		}
+		if !slices.Equal(node.WordsFormed, other.WordsFormed) {
+			diffs = append(diffs, fmt.Sprintf("%s: wordsFormed stored [%s], derived [%s]",
+				nodePath, strings.Join(node.WordsFormed, ","), strings.Join(other.WordsFormed, ",")))
+		}
+		if node.MaxDepthReached != other.MaxDepthReached {
+			diffs = append(diffs, fmt.Sprintf("%s: maxDepthReached stored %d, derived %d",
+				nodePath, node.MaxDepthReached, other.MaxDepthReached))
+		}
+		diffs = append(diffs, diffExplorationTrees(nodePath, node.NextMoves, other.NextMoves)...)
+	}
+	for _, node := range derived {
+		key := moveKey(node.Move)
+		if _, ok := storedByMove[key]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s > %s: derived move is missing from stored tree", path, key))
+		}
+	}
+	return diffs
+}
+
+func moveKey(move *engine.MoveOutput) string {
+	if move == nil {
+		return "<nil>"
+	}
+	return move.String()
+}
@@ -0,0 +1,450 @@
+package engine
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// MaxBoardCells is the largest grid, in cells, that Solve explores on a Board. Larger grids
+// fall back to the GameState explorer.
+const MaxBoardCells = 64
+
+// Board is a fixed-size grid state. Each cell holds the index of its letter in the alphabet of
+// the grid being explored rather than the letter itself, so boards are small comparable values
+// that are copied and compared without allocating.
+type Board [MaxBoardCells]byte
+
+// boardMove is a swap of two cells, given both as coordinates and as Board indices.
+type boardMove struct {
+	r1, c1, r2, c2 int
+	a, b           int
+}
+
+// boardExplorer explores a single grid on Boards. Everything that changes from move to move
+// lives in buffers that are reused across the whole exploration; only the returned tree and
+// the cache keys are allocated. An explorer is used by one goroutine at a time; explorers
+// forked from it share its memo and word table.
+type boardExplorer struct {
+	rules      Rules
+	dict       Dictionary
+	rows, cols int
+	moves      []boardMove
+	// alphabet holds the UTF-8 encoding of every letter index used on the board, and letters
+	// the letter itself.
+	alphabet [][]byte
+	letters  []rune
+	// trie is dict if it is a Trie, whose cursor rejects a window at its first letter that no
+	// word continues with.
+	trie   *Trie
+	cache  *stateCache
+	table  *wordTable
+	limits *exploreLimits
+
+	// words holds the words this explorer has looked up, indexed by their ID in table.
+	words []string
+	// windows memoizes the dictionary lookup of every window seen, keyed by its packed letter
+	// indices, as a word ID or -1. It is nil when windows are too long to pack.
+	windows map[uint64]int64
+
+	// found[d] holds the words found before depth d and formed[d] the words formed by the move
+	// under consideration at depth d.
+	found  [][]uint32
+	formed [][]uint32
+	word   []byte
+	key    []byte
+
+	// visited counts the states visited since the limits were last charged, and stopped is
+	// set once they have been exceeded.
+	visited int64
+	stopped bool
+}
+
+// newBoardExplorer prepares the exploration of grid. It reports false if the grid is not a
+// non-empty rectangle that fits in a Board. A nil cache disables memoization.
+func newBoardExplorer(rules Rules, grid Grid, dict Dictionary, cache *stateCache) (*boardExplorer, Board, bool) {
+	var board Board
+	rows := len(grid)
+	if rows == 0 || len(grid[0]) == 0 || rows*len(grid[0]) > MaxBoardCells {
+		return nil, board, false
+	}
+	cols := len(grid[0])
+	e := &boardExplorer{
+		rules: rules,
+		dict:  dict,
+		rows:  rows,
+		cols:  cols,
+		cache: cache,
+		table: &wordTable{ids: make(map[string]uint32)},
+	}
+	e.trie, _ = dict.(*Trie)
+	letters := make(map[rune]byte)
+	for r, row := range grid {
+		if len(row) != cols {
+			return nil, board, false
+		}
+		for c, letter := range row {
+			index, ok := letters[letter]
+			if !ok {
+				index = byte(len(e.alphabet))
+				letters[letter] = index
+				e.alphabet = append(e.alphabet, utf8.AppendRune(nil, letter))
+				e.letters = append(e.letters, letter)
+			}
+			board[r*cols+c] = index
+		}
+	}
+	// Moves are generated in (from, to) order, which is the order the tree is sorted in.
+	for r := range rows {
+		for c := range cols {
+			from := r*cols + c
+			if c+1 < cols {
+				e.moves = append(e.moves, boardMove{r1: r, c1: c, r2: r, c2: c + 1, a: from, b: from + 1})
+			}
+			if r+1 < rows {
+				e.moves = append(e.moves, boardMove{r1: r, c1: c, r2: r + 1, c2: c, a: from, b: from + cols})
+			}
+		}
+	}
+	e.reset()
+	return e, board, true
+}
+
+// fork returns an explorer for the same grid with its own buffers, sharing the memo and the
+// word table with e.
+func (e *boardExplorer) fork() *boardExplorer {
+	f := &boardExplorer{
+		rules:    e.rules,
+		dict:     e.dict,
+		rows:     e.rows,
+		cols:     e.cols,
+		moves:    e.moves,
+		alphabet: e.alphabet,
+		letters:  e.letters,
+		trie:     e.trie,
+		cache:    e.cache,
+		table:    e.table,
+		limits:   e.limits,
+	}
+	f.reset()
+	return f
+}
+
+// reset allocates the per-explorer buffers.
+func (e *boardExplorer) reset() {
+	depths := max(e.rules.MaxTurns, 0)
+	e.found = make([][]uint32, depths+2)
+	e.formed = make([][]uint32, depths+1)
+	if e.rules.WordLength*windowBits <= 64 {
+		e.windows = make(map[uint64]int64)
+	}
+}
+
+// explore is the Board counterpart of the package-level explore and returns the same results.
+func (e *boardExplorer) explore(board Board, depth int) ([]ExplorationNode, int, bool) {
+	if depth >= e.rules.MaxTurns {
+		return nil, 0, false
+	}
+	if e.cache != nil {
+		if cachedEntry, found := e.cache.load(e.stateKey(board, depth)); found {
+			return cachedEntry.Children, cachedEntry.MaxDepth, false
+		}
+	}
+	// Like the package-level explore, only states that are not memoized are charged.
+	if e.exhausted() {
+		// The result is discarded, but report it as incomplete so nothing above it is memoized.
+		return nil, 0, true
+	}
+
+	var children []ExplorationNode
+	maxDepth := 0
+	incomplete := false
+	for _, move := range e.moves {
+		node, ok, subIncomplete := e.exploreMove(board, depth, move)
+		if ok {
+			children = append(children, node)
+			maxDepth = max(maxDepth, 1+node.MaxDepthReached)
+			incomplete = incomplete || subIncomplete
+		}
+	}
+
+	if e.cache != nil && !incomplete {
+		// The key buffer was reused by the subtrees, so rebuild it.
+		e.cache.store(e.stateKey(board, depth), ExplorationCacheEntry{Children: children, MaxDepth: maxDepth})
+	}
+	return children, maxDepth, incomplete
+}
+
+// exploreRoot explores the moves from the initial board on up to workers goroutines, each
+// with an explorer forked from e. The result is the same as that of e.explore(board, 0):
+// the subtree below a state does not depend on how the state was reached, so it does not
+// matter which goroutine memoizes one first.
+func (e *boardExplorer) exploreRoot(board Board, workers int) ([]ExplorationNode, int) {
+	if e.rules.MaxTurns <= 0 {
+		return nil, 0
+	}
+	type result struct {
+		node ExplorationNode
+		ok   bool
+	}
+	results := make([]result, len(e.moves))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(e.moves)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := e.fork()
+			for i := range next {
+				results[i].node, results[i].ok, _ = f.exploreMove(board, 0, e.moves[i])
+			}
+			f.flush()
+		}()
+	}
+	for i := range e.moves {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var children []ExplorationNode
+	maxDepth := 0
+	for _, r := range results {
+		if r.ok {
+			children = append(children, r.node)
+			maxDepth = max(maxDepth, 1+r.node.MaxDepthReached)
+		}
+	}
+	return children, maxDepth
+}
+
+// exhausted counts a visited state and reports whether the limits have been exceeded.
+func (e *boardExplorer) exhausted() bool {
+	if e.limits == nil || e.stopped {
+		return e.stopped
+	}
+	e.visited++
+	if e.visited >= budgetCheckInterval {
+		e.stopped = e.limits.charge(e.visited)
+		e.visited = 0
+	}
+	return e.stopped
+}
+
+// flush charges the limits for the states visited since they were last charged.
+func (e *boardExplorer) flush() {
+	if e.limits != nil && !e.stopped {
+		e.stopped = e.limits.charge(e.visited)
+		e.visited = 0
+	}
+}
+
+// exploreMove applies move to board and explores the result if the swap forms a new word.
+func (e *boardExplorer) exploreMove(board Board, depth int, move boardMove) (ExplorationNode, bool, bool) {
+	board[move.a], board[move.b] = board[move.b], board[move.a]
+
+	e.formed[depth] = e.formed[depth][:0]
+	e.scanLine(board, depth, move.r1*e.cols, 1, e.cols)
+	if move.r2 != move.r1 {
+		e.scanLine(board, depth, move.r2*e.cols, 1, e.cols)
+	}
+	e.scanLine(board, depth, move.c1, e.cols, e.rows)
+	if move.c2 != move.c1 {
+		e.scanLine(board, depth, move.c2, e.cols, e.rows)
+	}
+	formed := e.formed[depth]
+	if len(formed) == 0 {
+		return ExplorationNode{}, false, false
+	}
+
+	wordsFormed := make([]string, len(formed))
+	for i, id := range formed {
+		wordsFormed[i] = e.words[id]
+	}
+	sort.Strings(wordsFormed)
+
+	next := append(e.found[depth+1][:0], e.found[depth]...)
+	next = append(next, formed...)
+	sortIDs(next)
+	e.found[depth+1] = next
+
+	subMoves, subDepth, incomplete := e.explore(board, depth+1)
+	return ExplorationNode{
+		Move:            &MoveOutput{From: [2]int{move.r1, move.c1}, To: [2]int{move.r2, move.c2}},
+		WordsFormed:     wordsFormed,
+		MaxDepthReached: subDepth,
+		NextMoves:       subMoves,
+	}, true, incomplete
+}
+
+// scanLine adds the words in the line of length cells starting at cell start and advancing by
+// step that were not found before depth to formed[depth].
+func (e *boardExplorer) scanLine(board Board, depth, start, step, length int) {
+	n := e.rules.WordLength
+	for offset := 0; offset+n <= length; offset++ {
+		first := start + offset*step
+		id, ok := e.lookupWindow(board, first, step)
+		if !ok || containsID(e.found[depth], id) || containsID(e.formed[depth], id) {
+			continue
+		}
+		e.formed[depth] = append(e.formed[depth], id)
+	}
+}
+
+// windowBits is the number of bits a letter index takes in a packed window. Boards have at
+// most MaxBoardCells distinct letters.
+const windowBits = 6
+
+// lookupWindow returns the ID of the word spelled by the WordLength cells starting at cell
+// first and advancing by step, and whether they spell a dictionary word.
+func (e *boardExplorer) lookupWindow(board Board, first, step int) (uint32, bool) {
+	n := e.rules.WordLength
+	var packed uint64
+	if e.windows != nil {
+		for i := range n {
+			packed = packed<<windowBits | uint64(board[first+i*step])
+		}
+		if id, ok := e.windows[packed]; ok {
+			return uint32(id), id >= 0
+		}
+	}
+
+	id := int64(-1)
+	if e.spellsWord(board, first, step) {
+		e.spell(board, first, step)
+		wordID, word := e.table.intern(e.word)
+		for len(e.words) <= int(wordID) {
+			e.words = append(e.words, "")
+		}
+		e.words[wordID] = word
+		id = int64(wordID)
+	}
+	if e.windows != nil {
+		e.windows[packed] = id
+	}
+	return uint32(id), id >= 0
+}
+
+// spellsWord reports whether the WordLength cells starting at cell first and advancing by step
+// spell a dictionary word.
+func (e *boardExplorer) spellsWord(board Board, first, step int) bool {
+	n := e.rules.WordLength
+	if e.trie == nil {
+		e.spell(board, first, step)
+		return e.dict.Contains(string(e.word))
+	}
+	cursor := e.trie.Root()
+	for i := range n {
+		var ok bool
+		if cursor, ok = cursor.Next(e.letters[board[first+i*step]]); !ok {
+			return false
+		}
+	}
+	return cursor.IsWord()
+}
+
+// spell encodes the WordLength cells starting at cell first and advancing by step into the word
+// buffer.
+func (e *boardExplorer) spell(board Board, first, step int) {
+	e.word = e.word[:0]
+	for i := range e.rules.WordLength {
+		e.word = append(e.word, e.alphabet[board[first+i*step]]...)
+	}
+}
+
+// stateKey encodes the board, the words found before depth and depth into the key buffer.
+// The same state always encodes to the same bytes because found-word sets are kept sorted.
+func (e *boardExplorer) stateKey(board Board, depth int) []byte {
+	e.key = append(e.key[:0], board[:e.rows*e.cols]...)
+	for _, id := range e.found[depth] {
+		e.key = binary.LittleEndian.AppendUint32(e.key, id)
+	}
+	e.key = binary.AppendUvarint(e.key, uint64(depth))
+	return e.key
+}
+
+// wordTable assigns IDs to the words found while exploring a grid. Every explorer of the grid
+// uses the same table, since the IDs are part of the memo keys.
+type wordTable struct {
+	mu    sync.Mutex
+	ids   map[string]uint32
+	words []string
+}
+
+// intern returns the ID of word, assigning the next one if the word is new, and the word as a string.
+func (t *wordTable) intern(word []byte) (uint32, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if id, ok := t.ids[string(word)]; ok {
+		return id, t.words[id]
+	}
+	id := uint32(len(t.words))
+	t.words = append(t.words, string(word))
+	t.ids[t.words[id]] = id
+	return id, t.words[id]
+}
+
+// cacheShards is the number of independently locked parts of a stateCache.
+const cacheShards = 64
+
+// stateCache is the memo of explored subtrees, keyed by boardExplorer.stateKey. It is sharded
+// so that explorers on different goroutines rarely wait for each other.
+type stateCache struct {
+	seed    maphash.Seed
+	entries atomic.Int64
+	shards  [cacheShards]struct {
+		mu      sync.Mutex
+		entries map[string]ExplorationCacheEntry
+	}
+}
+
+func newStateCache() *stateCache {
+	return &stateCache{seed: maphash.MakeSeed()}
+}
+
+func (c *stateCache) load(key []byte) (ExplorationCacheEntry, bool) {
+	shard := &c.shards[maphash.Bytes(c.seed, key)%cacheShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	entry, ok := shard.entries[string(key)]
+	return entry, ok
+}
+
+func (c *stateCache) store(key []byte, entry ExplorationCacheEntry) {
+	shard := &c.shards[maphash.Bytes(c.seed, key)%cacheShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if shard.entries == nil {
+		shard.entries = make(map[string]ExplorationCacheEntry)
+	}
+	if _, ok := shard.entries[string(key)]; !ok {
+		c.entries.Add(1)
+	}
+	shard.entries[string(key)] = entry
+}
+
+// len returns the number of memoized subtrees.
+func (c *stateCache) len() int64 {
+	return c.entries.Load()
+}
+
+func containsID(ids []uint32, id uint32) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sortIDs sorts a short slice of word IDs in place without allocating.
+func sortIDs(ids []uint32) {
+	for i := 1; i < len(ids); i++ {
+		for j := i; j > 0 && ids[j] < ids[j-1]; j-- {
+			ids[j], ids[j-1] = ids[j-1], ids[j]
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Budget limits the work spent exploring a single grid. Zero fields are unlimited.
+type Budget struct {
+	// MaxNodes is roughly the number of states that may be visited, not counting the states
+	// whose subtree is memoized. It is checked every budgetCheckInterval states per goroutine,
+	// so the exploration may overshoot it slightly.
+	MaxNodes int64
+	// MaxCacheEntries is the number of subtrees that may be memoized, which bounds the memory
+	// the memo takes.
+	MaxCacheEntries int64
+	// Timeout is the wall-clock time the exploration may take.
+	Timeout time.Duration
+}
+
+// ErrBudgetExceeded is returned, wrapped, when exploring a grid would exceed its Budget.
+var ErrBudgetExceeded = errors.New("exploration budget exceeded")
+
+// budgetCheckInterval is the number of states an explorer visits between checks of the limits.
+const budgetCheckInterval = 1024
+
+// exploreLimits tracks the budget of one exploration across all of its explorers.
+type exploreLimits struct {
+	ctx    context.Context
+	budget Budget
+	// entries returns the number of memoized subtrees.
+	entries func() int64
+	nodes   atomic.Int64
+
+	stopped atomic.Bool
+	mu      sync.Mutex
+	err     error
+}
+
+// charge adds nodes visited states and reports whether the exploration has to stop.
+func (l *exploreLimits) charge(nodes int64) bool {
+	total := l.nodes.Add(nodes)
+	switch {
+	case l.stopped.Load():
+	case l.budget.MaxNodes > 0 && total > l.budget.MaxNodes:
+		l.stop(fmt.Errorf("%w: visited more than %d states", ErrBudgetExceeded, l.budget.MaxNodes))
+	case l.budget.MaxCacheEntries > 0 && l.entries() > l.budget.MaxCacheEntries:
+		l.stop(fmt.Errorf("%w: memoized more than %d subtrees", ErrBudgetExceeded, l.budget.MaxCacheEntries))
+	case l.ctx.Err() != nil:
+		l.stop(context.Cause(l.ctx))
+	}
+	return l.stopped.Load()
+}
+
+// stop records the reason the exploration stopped, keeping the first one.
+func (l *exploreLimits) stop(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = err
+		l.stopped.Store(true)
+	}
+}
+
+// Err returns the reason the exploration stopped, or nil if it ran to completion.
+func (l *exploreLimits) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DagFormat is the value of the format field that marks a level in the DAG layout.
+const DagFormat = "dag"
+
+// DagExplorationOutput is the compact form of FullExplorationOutput. The exploration reaches
+// many states along several paths, and the tree form repeats their subtrees in full every time.
+// Here every distinct list of next moves is stored once in States and referred to by index.
+// States[0] is always the empty list, so moves that end the game leave Next unset.
+type DagExplorationOutput struct {
+	Format string `json:"format"`
+	LevelInfo
+	Root   int         `json:"root"`
+	States [][]DagMove `json:"states"`
+}
+
+// DagMove is an ExplorationNode whose next moves are the state at index Next.
+type DagMove struct {
+	Move            *MoveOutput `json:"move"`
+	WordsFormed     []string    `json:"wordsFormed"`
+	MaxDepthReached int         `json:"maxDepthReached"`
+	Next            int         `json:"next,omitempty"`
+}
+
+// ToDag converts a level to the DAG layout, sharing identical subtrees.
+func (level *FullExplorationOutput) ToDag() *DagExplorationOutput {
+	b := dagBuilder{ids: map[string]int{"": 0}, states: [][]DagMove{nil}}
+	return &DagExplorationOutput{
+		Format:    DagFormat,
+		LevelInfo: level.LevelInfo,
+		Root:      b.add(level.ExplorationTree),
+		States:    b.states,
+	}
+}
+
+// ToTree expands a level in the DAG layout back into the tree layout.
+func (dag *DagExplorationOutput) ToTree() (*FullExplorationOutput, error) {
+	tree, err := dag.expand(dag.Root, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &FullExplorationOutput{
+		LevelInfo:       dag.LevelInfo,
+		ExplorationTree: tree,
+	}, nil
+}
+
+func (dag *DagExplorationOutput) expand(id int, depth int) ([]ExplorationNode, error) {
+	if id < 0 || id >= len(dag.States) {
+		return nil, fmt.Errorf("state %d does not exist", id)
+	}
+	if depth > len(dag.States) {
+		return nil, fmt.Errorf("state %d is part of a cycle", id)
+	}
+	moves := dag.States[id]
+	if len(moves) == 0 {
+		return nil, nil
+	}
+	nodes := make([]ExplorationNode, len(moves))
+	for i, move := range moves {
+		next, err := dag.expand(move.Next, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = ExplorationNode{
+			Move:            move.Move,
+			WordsFormed:     move.WordsFormed,
+			MaxDepthReached: move.MaxDepthReached,
+			NextMoves:       next,
+		}
+	}
+	return nodes, nil
+}
+
+// dagBuilder assigns state IDs bottom-up. Because the children of a list are numbered before
+// the list itself, a list's key only needs the IDs of its children rather than their contents.
+type dagBuilder struct {
+	ids    map[string]int
+	states [][]DagMove
+}
+
+func (b *dagBuilder) add(nodes []ExplorationNode) int {
+	if len(nodes) == 0 {
+		return 0
+	}
+	moves := make([]DagMove, len(nodes))
+	var key strings.Builder
+	for i, node := range nodes {
+		moves[i] = DagMove{
+			Move:            node.Move,
+			WordsFormed:     node.WordsFormed,
+			MaxDepthReached: node.MaxDepthReached,
+			Next:            b.add(node.NextMoves),
+		}
+		if node.Move != nil {
+			key.WriteString(node.Move.String())
+		}
+		key.WriteRune(':')
+		key.WriteString(strings.Join(node.WordsFormed, ","))
+		key.WriteRune(':')
+		key.WriteString(strconv.Itoa(node.MaxDepthReached))
+		key.WriteRune(':')
+		key.WriteString(strconv.Itoa(moves[i].Next))
+		key.WriteRune(';')
+	}
+	if id, ok := b.ids[key.String()]; ok {
+		return id
+	}
+	id := len(b.states)
+	b.ids[key.String()] = id
+	b.states = append(b.states, moves)
+	return id
+}
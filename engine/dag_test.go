@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// TestDagRoundTrip checks that converting solved levels to the DAG layout and back, directly
+// and through JSON, gives back the same level.
+func TestDagRoundTrip(t *testing.T) {
+	dict := NewTrie(testWords)
+	rng := rand.New(rand.NewPCG(5, 6))
+	for range 20 {
+		grid := randomGrid(rng, 3, 3+rng.IntN(2), "aenstu")
+		rules := Rules{WordLength: 3, MaxTurns: 2 + rng.IntN(4)}
+		children, maxDepth := Solve(rules, grid, dict)
+		level := &FullExplorationOutput{
+			LevelInfo: LevelInfo{
+				SchemaVersion:    SchemaVersion,
+				InitialGrid:      ConvertGridToJsonGrid(grid),
+				WordLength:       rules.WordLength,
+				RequiredMaxTurns: rules.MaxTurns,
+				MaxDepthReached:  maxDepth,
+			},
+			ExplorationTree: children,
+		}
+
+		dag := level.ToDag()
+		if dag.Format != DagFormat || len(dag.States) == 0 || len(dag.States[0]) != 0 {
+			t.Fatalf("%s: malformed DAG: format %q, %d states", GridToString(grid), dag.Format, len(dag.States))
+		}
+		tree, err := dag.ToTree()
+		if err != nil {
+			t.Fatalf("%s: ToTree: %v", GridToString(grid), err)
+		}
+		if !reflect.DeepEqual(tree, level) {
+			t.Errorf("%s: ToTree(ToDag(level)) differs from level", GridToString(grid))
+		}
+
+		data, err := json.Marshal(dag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded DagExplorationOutput
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		tree, err = decoded.ToTree()
+		if err != nil {
+			t.Fatalf("%s: ToTree after JSON: %v", GridToString(grid), err)
+		}
+		want, _ := json.Marshal(level)
+		got, _ := json.Marshal(tree)
+		if string(got) != string(want) {
+			t.Errorf("%s: level differs after a JSON round trip through the DAG layout", GridToString(grid))
+		}
+	}
+}
+
+// TestDagSharesSubtrees checks that identical subtrees are stored once.
+func TestDagSharesSubtrees(t *testing.T) {
+	leaf := []ExplorationNode{{Move: &MoveOutput{From: [2]int{0, 0}, To: [2]int{0, 1}}, WordsFormed: []string{"tea"}}}
+	level := &FullExplorationOutput{ExplorationTree: []ExplorationNode{
+		{Move: &MoveOutput{From: [2]int{1, 0}, To: [2]int{1, 1}}, WordsFormed: []string{"ant"}, MaxDepthReached: 1, NextMoves: leaf},
+		{Move: &MoveOutput{From: [2]int{2, 0}, To: [2]int{2, 1}}, WordsFormed: []string{"net"}, MaxDepthReached: 1, NextMoves: leaf},
+	}}
+	dag := level.ToDag()
+	// The empty state, the shared leaf list and the root list.
+	if len(dag.States) != 3 {
+		t.Errorf("got %d states, want 3", len(dag.States))
+	}
+}
+
+func TestDagToTreeErrors(t *testing.T) {
+	move := &MoveOutput{From: [2]int{0, 0}, To: [2]int{0, 1}}
+	tests := []struct {
+		name string
+		dag  DagExplorationOutput
+	}{
+		{"missing root", DagExplorationOutput{Root: 2, States: [][]DagMove{nil}}},
+		{"missing state", DagExplorationOutput{Root: 1, States: [][]DagMove{nil, {{Move: move, Next: 5}}}}},
+		{"cycle", DagExplorationOutput{Root: 1, States: [][]DagMove{nil, {{Move: move, Next: 1}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.dag.ToTree(); err == nil {
+				t.Error("ToTree succeeded, want an error")
+			}
+		})
+	}
+}
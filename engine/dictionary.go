@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Dictionary answers the word queries the rules and the level filters need. Implementations
+// must be safe for concurrent use.
+type Dictionary interface {
+	// Contains reports whether word is in the dictionary.
+	Contains(word string) bool
+}
+
+// ContainsAll reports whether every word of the set is in the dictionary.
+func ContainsAll(dict Dictionary, wordSet FoundWordsSet) bool {
+	for word := range wordSet {
+		if !dict.Contains(word) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeWord returns word in the form dictionaries store it: lowercased and NFC-normalized, so
+// that a letter with an accent is a single rune, like a grid cell, wherever Unicode has a
+// precomposed form for it.
+func NormalizeWord(word string) string {
+	return norm.NFC.String(strings.ToLower(word))
+}
+
+// Trie is a Dictionary of normalized words of any length. It is built once and never modified,
+// so it can be shared between goroutines.
+type Trie struct {
+	nodes []trieNode
+	// edges holds the children of every node, sorted by letter. The children of a node are
+	// edges[node.first : node.first+node.count].
+	edges   []trieEdge
+	lengths map[int]int
+}
+
+type trieNode struct {
+	first, count int32
+	word         bool
+}
+
+type trieEdge struct {
+	letter rune
+	node   int32
+}
+
+// NewTrie builds a trie from a word list. Words are normalized with NormalizeWord before they
+// are stored, and lengths are counted in runes.
+func NewTrie(words []string) *Trie {
+	sorted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word != "" {
+			sorted = append(sorted, NormalizeWord(word))
+		}
+	}
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	t := &Trie{nodes: []trieNode{{}}, lengths: make(map[int]int)}
+	t.build(0, sorted, 0)
+	return t
+}
+
+// ParseTrie builds a trie from whitespace-separated words, as found in the embedded word lists.
+func ParseTrie(wordlist string) *Trie {
+	return NewTrie(strings.Fields(wordlist))
+}
+
+// build fills in node, which spells the first offset bytes shared by every word of the sorted
+// list words. The children of a node are reserved before any of them is built, so that they
+// are contiguous in t.edges.
+func (t *Trie) build(node int32, words []string, offset int) {
+	if len(words) > 0 && len(words[0]) == offset {
+		t.nodes[node].word = true
+		t.lengths[utf8.RuneCountInString(words[0])]++
+		words = words[1:]
+	}
+
+	type group struct {
+		letter rune
+		size   int
+		words  []string
+	}
+	var groups []group
+	for start := 0; start < len(words); {
+		letter, size := utf8.DecodeRuneInString(words[start][offset:])
+		end := start + 1
+		for end < len(words) && strings.HasPrefix(words[end][offset:], words[start][offset:offset+size]) {
+			end++
+		}
+		groups = append(groups, group{letter: letter, size: size, words: words[start:end]})
+		start = end
+	}
+
+	first := int32(len(t.edges))
+	t.nodes[node].first, t.nodes[node].count = first, int32(len(groups))
+	for _, g := range groups {
+		t.edges = append(t.edges, trieEdge{letter: g.letter, node: int32(len(t.nodes))})
+		t.nodes = append(t.nodes, trieNode{})
+	}
+	for i, g := range groups {
+		t.build(t.edges[first+int32(i)].node, g.words, offset+g.size)
+	}
+}
+
+// Len returns the number of words in the trie.
+func (t *Trie) Len() int {
+	total := 0
+	for _, n := range t.lengths {
+		total += n
+	}
+	return total
+}
+
+// LenOfLength returns the number of words of the given length, in letters, in the trie.
+func (t *Trie) LenOfLength(length int) int {
+	return t.lengths[length]
+}
+
+// WordsOfLength returns the words of the given length, in letters, in sorted order.
+func (t *Trie) WordsOfLength(length int) []string {
+	words := make([]string, 0, t.lengths[length])
+	var walk func(node int32, prefix []rune)
+	walk = func(node int32, prefix []rune) {
+		n := t.nodes[node]
+		if len(prefix) == length {
+			if n.word {
+				words = append(words, string(prefix))
+			}
+			return
+		}
+		for _, edge := range t.edges[n.first : n.first+n.count] {
+			walk(edge.node, append(prefix, edge.letter))
+		}
+	}
+	walk(0, make([]rune, 0, length))
+	return words
+}
+
+// Contains reports whether word is in the trie.
+func (t *Trie) Contains(word string) bool {
+	cursor, ok := t.Root().WalkString(word)
+	return ok && cursor.IsWord()
+}
+
+// Root returns a cursor at the empty prefix.
+func (t *Trie) Root() TrieCursor {
+	return TrieCursor{trie: t}
+}
+
+// TrieCursor is a position in a Trie, standing for the prefix spelled on the way there. Stepping
+// a cursor letter by letter answers prefix queries for a growing word without starting over.
+type TrieCursor struct {
+	trie *Trie
+	node int32
+}
+
+// Next returns the cursor for the current prefix followed by letter, and false if no word starts
+// with that prefix.
+func (c TrieCursor) Next(letter rune) (TrieCursor, bool) {
+	node := c.trie.nodes[c.node]
+	edges := c.trie.edges[node.first : node.first+node.count]
+	i, found := slices.BinarySearchFunc(edges, letter, func(e trieEdge, letter rune) int {
+		return int(e.letter - letter)
+	})
+	if !found {
+		return TrieCursor{}, false
+	}
+	return TrieCursor{trie: c.trie, node: edges[i].node}, true
+}
+
+// WalkString steps the cursor through every letter of s.
+func (c TrieCursor) WalkString(s string) (TrieCursor, bool) {
+	for _, letter := range s {
+		var ok bool
+		if c, ok = c.Next(letter); !ok {
+			return TrieCursor{}, false
+		}
+	}
+	return c, true
+}
+
+// IsWord reports whether the prefix at the cursor is itself a word.
+func (c TrieCursor) IsWord() bool {
+	return c.trie.nodes[c.node].word
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	// café is given with a combining accent, which NewTrie composes into a single letter.
+	trie := NewTrie([]string{"tea", "Team", "teams", "ten", "a", "cafe\u0301", "tea", ""})
+
+	contains := map[string]bool{
+		"tea": true, "team": true, "teams": true, "ten": true, "a": true, "café": true,
+		"": false, "t": false, "te": false, "tean": false, "teamsx": false, "cafe": false, "b": false,
+	}
+	for word, want := range contains {
+		if got := trie.Contains(word); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", word, got, want)
+		}
+	}
+
+	hasPrefix := map[string]bool{
+		"": true, "t": true, "te": true, "tea": true, "teams": true, "caf": true, "café": true,
+		"x": false, "teb": false, "teamsx": false,
+	}
+	for prefix, want := range hasPrefix {
+		if _, got := trie.Root().WalkString(prefix); got != want {
+			t.Errorf("WalkString(%q) = %v, want %v", prefix, got, want)
+		}
+	}
+
+	if got := trie.Len(); got != 6 {
+		t.Errorf("Len() = %d, want 6", got)
+	}
+	lengths := map[int][]string{
+		0: {},
+		1: {"a"},
+		3: {"tea", "ten"},
+		4: {"café", "team"},
+		5: {"teams"},
+		6: {},
+	}
+	for length, want := range lengths {
+		if got := trie.WordsOfLength(length); !reflect.DeepEqual(got, want) {
+			t.Errorf("WordsOfLength(%d) = %q, want %q", length, got, want)
+		}
+		if got := trie.LenOfLength(length); got != len(want) {
+			t.Errorf("LenOfLength(%d) = %d, want %d", length, got, len(want))
+		}
+	}
+}
+
+func TestParseTrie(t *testing.T) {
+	trie := ParseTrie("one two\nthree\t four\n\n")
+	if got, want := trie.WordsOfLength(3), []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WordsOfLength(3) = %q, want %q", got, want)
+	}
+	if !trie.Contains("three") || !trie.Contains("four") {
+		t.Error("words after line breaks and tabs are missing")
+	}
+}
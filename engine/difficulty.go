@@ -0,0 +1,53 @@
+package engine
+
+// DifficultyWeights weigh the features that make up a difficulty score. They are normalized by
+// their sum, so only their ratios matter.
+type DifficultyWeights struct {
+	Depth    float64 `json:"depth" yaml:"depth"`
+	Scarcity float64 `json:"scarcity" yaml:"scarcity"`
+	Traps    float64 `json:"traps" yaml:"traps"`
+	Rarity   float64 `json:"rarity" yaml:"rarity"`
+}
+
+// DefaultDifficultyWeights favour depth, then how easy it is to go wrong.
+var DefaultDifficultyWeights = DifficultyWeights{Depth: 0.4, Scarcity: 0.2, Traps: 0.25, Rarity: 0.15}
+
+// Classification scores how hard a level is. Every feature and the score are between 0 and 1,
+// so scores of levels with the same grid size and rules can be compared from day to day.
+type Classification struct {
+	Score float64 `json:"score"`
+	// Depth is the depth reached relative to the turn limit.
+	Depth float64 `json:"depth"`
+	// Scarcity is the share of complete games that are not optimal.
+	Scarcity float64 `json:"scarcity"`
+	// Traps is Metrics.TrapRatio.
+	Traps float64 `json:"traps"`
+	// Rarity is the average rarity of the words in the tree, as judged by the rarity function.
+	Rarity float64 `json:"rarity"`
+}
+
+// Classify scores a level from its metrics. rarity returns a value between 0 for the most common
+// words and 1 for the rarest; a nil rarity rates every word as common.
+func Classify(metrics Metrics, maxDepth, maxTurns int, words FoundWordsSet, rarity func(word string) float64, weights DifficultyWeights) Classification {
+	var c Classification
+	if maxTurns > 0 {
+		c.Depth = min(float64(maxDepth)/float64(maxTurns), 1)
+	}
+	if metrics.CompletePaths > 0 {
+		c.Scarcity = 1 - float64(metrics.OptimalPaths)/float64(metrics.CompletePaths)
+	}
+	c.Traps = metrics.TrapRatio
+	if rarity != nil && len(words) > 0 {
+		for word := range words {
+			c.Rarity += rarity(word)
+		}
+		c.Rarity /= float64(len(words))
+	}
+
+	total := weights.Depth + weights.Scarcity + weights.Traps + weights.Rarity
+	if total > 0 {
+		c.Score = (weights.Depth*c.Depth + weights.Scarcity*c.Scarcity +
+			weights.Traps*c.Traps + weights.Rarity*c.Rarity) / total
+	}
+	return c
+}
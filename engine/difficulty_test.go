@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	metrics := ComputeMetrics(metricsTree(), 2, 3)
+	words := FoundWordsSet{"ant": {}, "tea": {}, "sea": {}, "sun": {}, "ten": {}}
+	rarity := func(word string) float64 {
+		if word == "sun" {
+			return 1
+		}
+		return 0
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	got := Classify(metrics, 2, 3, words, rarity, DifficultyWeights{Depth: 1, Scarcity: 1, Traps: 1, Rarity: 1})
+	want := Classification{Depth: 2.0 / 3, Scarcity: 1.0 / 4, Traps: 1.0 / 6, Rarity: 1.0 / 5}
+	want.Score = (want.Depth + want.Scarcity + want.Traps + want.Rarity) / 4
+	if !near(got.Score, want.Score) || !near(got.Depth, want.Depth) || !near(got.Scarcity, want.Scarcity) ||
+		!near(got.Traps, want.Traps) || !near(got.Rarity, want.Rarity) {
+		t.Errorf("Classify = %+v, want %+v", got, want)
+	}
+
+	// Weights only count relative to each other.
+	if scaled := Classify(metrics, 2, 3, words, rarity, DifficultyWeights{Depth: 3, Scarcity: 3, Traps: 3, Rarity: 3}); !near(scaled.Score, got.Score) {
+		t.Errorf("scaled weights: score %v, want %v", scaled.Score, got.Score)
+	}
+	if depthOnly := Classify(metrics, 2, 3, words, rarity, DifficultyWeights{Depth: 1}); !near(depthOnly.Score, 2.0/3) {
+		t.Errorf("depth weight only: score %v, want 2/3", depthOnly.Score)
+	}
+
+	// A level that uses every turn with a single optimal game and no traps or rare words.
+	easy := Classify(Metrics{OptimalPaths: 1, CompletePaths: 1}, 4, 3, words, nil, DefaultDifficultyWeights)
+	if easy.Depth != 1 || easy.Scarcity != 0 || easy.Rarity != 0 || !near(easy.Score, DefaultDifficultyWeights.Depth) {
+		t.Errorf("Classify = %+v, want depth 1 clamped and score %v", easy, DefaultDifficultyWeights.Depth)
+	}
+	if none := Classify(metrics, 2, 3, words, rarity, DifficultyWeights{}); none.Score != 0 {
+		t.Errorf("zero weights: score %v, want 0", none.Score)
+	}
+}
@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Solve explores every move sequence reachable from grid and returns the exploration tree
+// together with the maximum depth reached. Grids of up to MaxBoardCells cells are explored on
+// Boards, which is much faster than exploring GameStates and gives the same tree.
+func Solve(rules Rules, grid Grid, dict Dictionary) ([]ExplorationNode, int) {
+	return SolveParallel(rules, grid, dict, 1)
+}
+
+// SolveParallel is like Solve but splits the moves from grid across up to workers goroutines
+// that share one memo. It returns the same tree as Solve, and it is worth using when a single
+// grid has to be explored quickly rather than many grids in parallel.
+func SolveParallel(rules Rules, grid Grid, dict Dictionary, workers int) ([]ExplorationNode, int) {
+	children, maxDepth, _ := SolveContext(context.Background(), rules, grid, dict, SolveOptions{Workers: workers})
+	return children, maxDepth
+}
+
+// SolveOptions configures SolveContext.
+type SolveOptions struct {
+	// Workers is the number of goroutines exploring the grid, as for SolveParallel.
+	Workers int
+	// Budget limits the work spent on the grid.
+	Budget Budget
+}
+
+// SolveContext is like SolveParallel but gives up when ctx is done or the exploration exceeds
+// opts.Budget. The error then wraps ErrBudgetExceeded or is the cause of ctx being done.
+func SolveContext(ctx context.Context, rules Rules, grid Grid, dict Dictionary, opts SolveOptions) ([]ExplorationNode, int, error) {
+	if opts.Budget.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Budget.Timeout,
+			fmt.Errorf("%w: took longer than %v", ErrBudgetExceeded, opts.Budget.Timeout))
+		defer cancel()
+	}
+	limited := ctx.Done() != nil || opts.Budget != (Budget{})
+
+	e, board, ok := newBoardExplorer(rules, grid, dict, newStateCache())
+	if !ok {
+		initialState := GameState{Grid: grid, FoundWords: make(FoundWordsSet)}
+		cache := make(map[string]ExplorationCacheEntry)
+		var limits *exploreLimits
+		if limited {
+			limits = &exploreLimits{ctx: ctx, budget: opts.Budget, entries: func() int64 { return int64(len(cache)) }}
+		}
+		children, maxDepth, _ := explore(rules, initialState, dict, 0, cache, limits)
+		if limits != nil {
+			if err := limits.Err(); err != nil {
+				return nil, 0, err
+			}
+		}
+		return children, maxDepth, nil
+	}
+	if limited {
+		e.limits = &exploreLimits{ctx: ctx, budget: opts.Budget, entries: e.cache.len}
+	}
+
+	var children []ExplorationNode
+	var maxDepth int
+	if opts.Workers > 1 {
+		children, maxDepth = e.exploreRoot(board, opts.Workers)
+	} else {
+		children, maxDepth, _ = e.explore(board, 0)
+	}
+	if e.limits != nil {
+		e.flush()
+		if err := e.limits.Err(); err != nil {
+			return nil, 0, err
+		}
+	}
+	return children, maxDepth, nil
+}
+
+// SolveUncached is like Solve but explores GameStates and re-derives every subtree instead of
+// memoizing them. It is much slower and exists to cross-check the optimized exploration.
+func SolveUncached(rules Rules, grid Grid, dict Dictionary) ([]ExplorationNode, int) {
+	initialState := GameState{Grid: grid, FoundWords: make(FoundWordsSet)}
+	return Explore(rules, initialState, dict, 0, nil)
+}
+
+// StateKey returns the canonical memoization key for a game state. The moves available from
+// a state depend on the grid and on the words already found, and how far they can be followed
+// depends on the depth at which the state is reached, so all three are part of the key.
+func StateKey(state GameState, currentDepth int) string {
+	foundWords := make([]string, 0, len(state.FoundWords))
+	for word := range state.FoundWords {
+		foundWords = append(foundWords, word)
+	}
+	sort.Strings(foundWords)
+
+	var sb strings.Builder
+	sb.WriteString(GridToString(state.Grid))
+	sb.WriteString(strings.Join(foundWords, ","))
+	sb.WriteRune('|')
+	sb.WriteString(strconv.Itoa(currentDepth))
+	return sb.String()
+}
+
+// --- Recursive Exploration Function ---
+
+// Explore returns the exploration tree below currentState and the maximum number of moves
+// that can still be made from it. globalExplorationCache memoizes subtrees by StateKey; a nil
+// cache disables memoization. Every move finds a new word, so no state repeats along a path,
+// and the subtree below a state only depends on the state: memoizing it never changes the tree.
+func Explore(rules Rules, currentState GameState, wordMap Dictionary, currentDepth int, globalExplorationCache map[string]ExplorationCacheEntry) ([]ExplorationNode, int) {
+	children, maxDepth, _ := explore(rules, currentState, wordMap, currentDepth, globalExplorationCache, nil)
+	return children, maxDepth
+}
+
+// explore implements Explore. Every state visited is charged to limits, if there are any; once
+// they are exceeded the exploration unwinds with incomplete subtrees, which are reported as
+// such and not cached.
+func explore(rules Rules, currentState GameState, wordMap Dictionary, currentDepth int, globalExplorationCache map[string]ExplorationCacheEntry, limits *exploreLimits) ([]ExplorationNode, int, bool) {
+	var children []ExplorationNode
+	maxDepthFromCurrentState := 0
+	incomplete := false
+	if currentDepth >= rules.MaxTurns {
+		return nil, 0, false
+	}
+	stateKey := StateKey(currentState, currentDepth)
+	if cachedEntry, found := globalExplorationCache[stateKey]; found {
+		return cachedEntry.Children, cachedEntry.MaxDepth, false
+	}
+	if limits != nil && limits.charge(1) {
+		return nil, 0, true
+	}
+	rows := len(currentState.Grid)
+	if rows == 0 || len(currentState.Grid[0]) == 0 {
+		return nil, 0, false
+	}
+	cols := len(currentState.Grid[0])
+	for r := range rows {
+		for c := range cols {
+			currentCell := Coordinates{Row: r, Col: c}
+			neighbors := []Coordinates{}
+			if c+1 < cols {
+				neighbors = append(neighbors, Coordinates{Row: r, Col: c + 1})
+			}
+			if r+1 < rows {
+				neighbors = append(neighbors, Coordinates{Row: r + 1, Col: c})
+			}
+			for _, neighbor := range neighbors {
+				potentialMoveInternal := Move{Cell1: currentCell, Cell2: neighbor}
+				nextGrid := ApplyMove(currentState.Grid, potentialMoveInternal)
+				if nextGrid == nil {
+					continue
+				}
+				newlyFoundWords := FindNewWords(rules, nextGrid, potentialMoveInternal, wordMap, currentState.FoundWords)
+				if len(newlyFoundWords) > 0 {
+					moveOut := MoveOutput{From: [2]int{currentCell.Row, currentCell.Col}, To: [2]int{neighbor.Row, neighbor.Col}}
+					newFoundSet := CopyFoundWords(currentState.FoundWords)
+					for _, word := range newlyFoundWords {
+						newFoundSet[word] = struct{}{}
+					}
+					nextState := GameState{Grid: nextGrid, FoundWords: newFoundSet}
+					subMoves, depthFromSubMove, subIncomplete := explore(rules, nextState, wordMap, currentDepth+1, globalExplorationCache, limits)
+					incomplete = incomplete || subIncomplete
+					currentBranchTotalDepth := 1 + depthFromSubMove
+					if currentBranchTotalDepth > maxDepthFromCurrentState {
+						maxDepthFromCurrentState = currentBranchTotalDepth
+					}
+					node := ExplorationNode{Move: &moveOut, WordsFormed: newlyFoundWords, MaxDepthReached: depthFromSubMove, NextMoves: subMoves}
+					children = append(children, node)
+				}
+			}
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].Move == nil {
+			return false
+		}
+		if children[j].Move == nil {
+			return true
+		}
+		m1, m2 := children[i].Move, children[j].Move
+		if m1.From[0] != m2.From[0] {
+			return m1.From[0] < m2.From[0]
+		}
+		if m1.From[1] != m2.From[1] {
+			return m1.From[1] < m2.From[1]
+		}
+		if m1.To[0] != m2.To[0] {
+			return m1.To[0] < m2.To[0]
+		}
+		return m1.To[1] < m2.To[1]
+	})
+	if globalExplorationCache != nil && !incomplete {
+		globalExplorationCache[stateKey] = ExplorationCacheEntry{Children: children, MaxDepth: maxDepthFromCurrentState}
+	}
+	return children, maxDepthFromCurrentState, incomplete
+}
+
+// CollectAllWords recursively traverses the exploration tree and gathers all unique words.
+func CollectAllWords(nodes []ExplorationNode, allWords FoundWordsSet) {
+	if allWords == nil {
+		return
+	}
+	for i := range nodes {
+		node := nodes[i]
+		for _, word := range node.WordsFormed {
+			allWords[word] = struct{}{}
+		}
+		if len(node.NextMoves) > 0 {
+			CollectAllWords(node.NextMoves, allWords)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// testWords is a small dictionary over few letters, so that random grids of those letters
+// spell plenty of words.
+var testWords = []string{
+	"ant", "ate", "eat", "eta", "net", "nut", "sat", "sea", "set", "sun",
+	"tan", "tea", "ten", "tun", "use", "east", "neat", "nest", "nets", "sane",
+	"seat", "sent", "stun", "tans", "teas", "tens", "tuna", "tune", "unset", "usen",
+}
+
+// randomGrid returns a grid of the given size with letters drawn from letters.
+func randomGrid(rng *rand.Rand, rows, cols int, letters string) Grid {
+	alphabet := []rune(letters)
+	grid := make(Grid, rows)
+	for r := range grid {
+		grid[r] = make([]rune, cols)
+		for c := range grid[r] {
+			grid[r][c] = alphabet[rng.IntN(len(alphabet))]
+		}
+	}
+	return grid
+}
+
+// FuzzExplorersAgree checks that the Board explorer, its parallel version and the GameState
+// explorer, with and without memoization, return the same tree for the random grid and rules
+// drawn from seed.
+func FuzzExplorersAgree(f *testing.F) {
+	for seed := range uint64(60) {
+		f.Add(seed)
+	}
+	dict := NewTrie(testWords)
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		rng := rand.New(rand.NewPCG(seed, 0))
+		rows, cols := 2+rng.IntN(3), 2+rng.IntN(3)
+		rules := Rules{WordLength: 3 + rng.IntN(2), MaxTurns: 1 + rng.IntN(5)}
+		grid := randomGrid(rng, rows, cols, "aenstu")
+
+		children, maxDepth := Solve(rules, grid, dict)
+
+		parallel, parallelDepth := SolveParallel(rules, grid, dict, 4)
+		if parallelDepth != maxDepth || !reflect.DeepEqual(parallel, children) {
+			t.Errorf("%s: SolveParallel differs from Solve: depth %d, want %d", GridToString(grid), parallelDepth, maxDepth)
+		}
+
+		state := GameState{Grid: grid, FoundWords: make(FoundWordsSet)}
+		explored, exploredDepth := Explore(rules, state, dict, 0, make(map[string]ExplorationCacheEntry))
+		if exploredDepth != maxDepth || !reflect.DeepEqual(explored, children) {
+			t.Errorf("%s: Explore differs from Solve: depth %d, want %d", GridToString(grid), exploredDepth, maxDepth)
+		}
+
+		uncached, uncachedDepth := SolveUncached(rules, grid, dict)
+		if uncachedDepth != maxDepth || !reflect.DeepEqual(uncached, children) {
+			t.Errorf("%s: SolveUncached differs from Solve: depth %d, want %d", GridToString(grid), uncachedDepth, maxDepth)
+		}
+
+		// Both explorers charge the same states to a budget, so a budget of exactly the states
+		// explore visits is enough for Solve, and one state less is not.
+		counter := &exploreLimits{ctx: context.Background(), entries: func() int64 { return 0 }}
+		explore(rules, state, dict, 0, make(map[string]ExplorationCacheEntry), counter)
+		visited := counter.nodes.Load()
+		for _, maxNodes := range []int64{visited - 1, visited} {
+			if maxNodes <= 0 {
+				continue
+			}
+			budget := Budget{MaxNodes: maxNodes}
+			limits := &exploreLimits{ctx: context.Background(), budget: budget, entries: func() int64 { return 0 }}
+			explore(rules, state, dict, 0, make(map[string]ExplorationCacheEntry), limits)
+			_, _, err := SolveContext(context.Background(), rules, grid, dict, SolveOptions{Budget: budget})
+			if exceeded := errors.Is(limits.Err(), ErrBudgetExceeded); errors.Is(err, ErrBudgetExceeded) != exceeded || exceeded != (maxNodes < visited) {
+				t.Errorf("%s: with a budget of %d of %d states, Solve got error %v and explore %v", GridToString(grid), maxNodes, visited, err, limits.Err())
+			}
+		}
+	})
+}
+
+// TestSolveContextBudget checks that both explorers stop once the budget is exceeded.
+func TestSolveContextBudget(t *testing.T) {
+	dict := NewTrie(testWords)
+	rules := Rules{WordLength: 3, MaxTurns: 8}
+	rng := rand.New(rand.NewPCG(3, 4))
+	for _, size := range [][2]int{{4, 4}, {9, 8}} {
+		grid := randomGrid(rng, size[0], size[1], "aenstu")
+		_, _, err := SolveContext(context.Background(), rules, grid, dict, SolveOptions{Budget: Budget{MaxNodes: 10}})
+		if !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("%dx%d grid: got error %v, want ErrBudgetExceeded", size[0], size[1], err)
+		}
+	}
+}
+
+// benchmarkGrid is a grid with a deep exploration tree in testWords.
+var benchmarkGrid = Grid{
+	[]rune("stea"),
+	[]rune("unts"),
+	[]rune("eant"),
+}
+
+func BenchmarkSolve(b *testing.B) {
+	dict := NewTrie(testWords)
+	rules := Rules{WordLength: 3, MaxTurns: 6}
+	for b.Loop() {
+		Solve(rules, benchmarkGrid, dict)
+	}
+}
+
+func BenchmarkSolveParallel(b *testing.B) {
+	dict := NewTrie(testWords)
+	rules := Rules{WordLength: 3, MaxTurns: 6}
+	for b.Loop() {
+		SolveParallel(rules, benchmarkGrid, dict, 4)
+	}
+}
+
+// BenchmarkExplore explores the same grid as BenchmarkSolve on GameStates, which Solve used to
+// do for every grid.
+func BenchmarkExplore(b *testing.B) {
+	dict := NewTrie(testWords)
+	rules := Rules{WordLength: 3, MaxTurns: 6}
+	for b.Loop() {
+		state := GameState{Grid: benchmarkGrid, FoundWords: make(FoundWordsSet)}
+		Explore(rules, state, dict, 0, make(map[string]ExplorationCacheEntry))
+	}
+}
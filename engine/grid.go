@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// GridToString returns a compact string form of the grid, suitable for use as a map key.
+func GridToString(grid Grid) string {
+	if grid == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	for _, row := range grid {
+		buf.WriteString(string(row))
+		buf.WriteRune('|')
+	}
+	return buf.String()
+}
+
+// ParseGrid parses a grid written as rows separated by '/', such as "sact/tnek/onhw".
+// Letters are normalized like dictionary words, so an accented letter typed as a base letter and
+// a combining mark fills one cell, and every row must have the same length.
+func ParseGrid(s string) (Grid, error) {
+	rowStrs := strings.Split(NormalizeWord(strings.TrimSpace(s)), "/")
+	grid := make(Grid, len(rowStrs))
+	for r, rowStr := range rowStrs {
+		grid[r] = []rune(strings.TrimSpace(rowStr))
+		if len(grid[r]) == 0 {
+			return nil, fmt.Errorf("row %d of grid %q is empty", r, s)
+		}
+		if len(grid[r]) != len(grid[0]) {
+			return nil, fmt.Errorf("row %d of grid %q has %d letters, expected %d", r, s, len(grid[r]), len(grid[0]))
+		}
+	}
+	return grid, nil
+}
+
+// ConvertGridToJsonGrid converts a grid into its JSON representation of single-letter strings.
+func ConvertGridToJsonGrid(grid Grid) JsonGrid {
+	if grid == nil {
+		return nil
+	}
+	jsonGrid := make(JsonGrid, len(grid))
+	for r, row := range grid {
+		jsonGrid[r] = make([]string, len(row))
+		for c, cell := range row {
+			jsonGrid[r][c] = string(cell)
+		}
+	}
+	return jsonGrid
+}
+
+// ConvertJsonGridToGrid is the inverse of ConvertGridToJsonGrid. Cells are NFC-normalized, and
+// a cell that is not a single letter once normalized is an error.
+func ConvertJsonGridToGrid(jsonGrid JsonGrid) (Grid, error) {
+	if jsonGrid == nil {
+		return nil, nil
+	}
+	grid := make(Grid, len(jsonGrid))
+	for r, row := range jsonGrid {
+		grid[r] = make([]rune, len(row))
+		for c, cell := range row {
+			letters := []rune(norm.NFC.String(cell))
+			if len(letters) != 1 {
+				return nil, fmt.Errorf("cell (%d,%d) of the grid is %q, expected a single letter", r, c, cell)
+			}
+			grid[r][c] = letters[0]
+		}
+	}
+	return grid, nil
+}
+
+// CopyFoundWords returns a shallow copy of a found-words set.
+func CopyFoundWords(foundWords FoundWordsSet) FoundWordsSet {
+	newSet := make(FoundWordsSet, len(foundWords))
+	maps.Copy(newSet, foundWords)
+	return newSet
+}
+
+// CopyGrid returns a deep copy of the grid.
+func CopyGrid(grid Grid) Grid {
+	if grid == nil {
+		return nil
+	}
+	rows := len(grid)
+	if rows == 0 {
+		return Grid{}
+	}
+	if len(grid[0]) == 0 {
+		newGrid := make(Grid, rows)
+		for r := range newGrid {
+			newGrid[r] = make([]rune, 0)
+		}
+		return newGrid
+	}
+	cols := len(grid[0])
+	newGrid := make(Grid, rows)
+	for r := range grid {
+		newGrid[r] = make([]rune, cols)
+		copy(newGrid[r], grid[r])
+	}
+	return newGrid
+}
+
+// ApplyMove returns a copy of the grid with the two cells of the move swapped.
+// It returns nil if either cell is out of bounds.
+func ApplyMove(grid Grid, move Move) Grid {
+	newGrid := CopyGrid(grid)
+	if newGrid == nil {
+		return nil
+	}
+	c1, c2 := move.Cell1, move.Cell2
+	rows := len(newGrid)
+	if rows == 0 || len(newGrid[0]) == 0 {
+		return newGrid
+	}
+	cols := len(newGrid[0])
+	if !(c1.Row >= 0 && c1.Row < rows && c1.Col >= 0 && c1.Col < cols &&
+		c2.Row >= 0 && c2.Row < rows && c2.Col >= 0 && c2.Col < cols) {
+		return nil
+	}
+	newGrid[c1.Row][c1.Col], newGrid[c2.Row][c2.Col] = newGrid[c2.Row][c2.Col], newGrid[c1.Row][c1.Col]
+	return newGrid
+}
@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertJsonGridToGrid(t *testing.T) {
+	grid, err := ConvertJsonGridToGrid(JsonGrid{{"c", "a"}, {"é", "t"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Grid{{'c', 'a'}, {'é', 't'}}); !reflect.DeepEqual(grid, want) {
+		t.Errorf("ConvertJsonGridToGrid = %q, want %q", grid, want)
+	}
+	if back := ConvertGridToJsonGrid(grid); !reflect.DeepEqual(back, JsonGrid{{"c", "a"}, {"é", "t"}}) {
+		t.Errorf("ConvertGridToJsonGrid = %q", back)
+	}
+
+	for _, cell := range []string{"", "ab", "é́"} {
+		if _, err := ConvertJsonGridToGrid(JsonGrid{{"c", cell}}); err == nil {
+			t.Errorf("ConvertJsonGridToGrid accepted the cell %q", cell)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package engine
+
+// Metrics quantify how a level plays, beyond how deep it goes.
+type Metrics struct {
+	// OptimalPaths is the number of distinct move sequences that reach MaxDepthReached.
+	OptimalPaths int64 `json:"optimalPaths"`
+	// CompletePaths is the number of distinct move sequences that end the game, optimal or not.
+	CompletePaths int64 `json:"completePaths"`
+	// BranchingFactor is the average number of moves available from the states at each depth,
+	// starting with the initial grid at depth 0.
+	BranchingFactor []float64 `json:"branchingFactor"`
+	// DeadEndRatio is the fraction of states before the turn limit from which no move forms a word.
+	DeadEndRatio float64 `json:"deadEndRatio"`
+	// TrapRatio is the fraction of moves that lose depth: they end up shorter than the best
+	// move available from the same state.
+	TrapRatio float64 `json:"trapRatio"`
+	// FirstMoves is the number of moves available from the initial grid.
+	FirstMoves int `json:"firstMoves"`
+	// TotalNodes is the number of nodes in the exploration tree.
+	TotalNodes int64 `json:"totalNodes"`
+	// UniqueWords is the number of distinct words formed anywhere in the tree.
+	UniqueWords int `json:"uniqueWords"`
+}
+
+// ComputeMetrics measures the exploration tree of a level explored up to maxTurns turns.
+// Subtrees shared between several parents, as Solve returns them, are only walked once.
+func ComputeMetrics(tree []ExplorationNode, maxDepth int, maxTurns int) Metrics {
+	m := metricsWalker{
+		maxTurns: maxTurns,
+		stats:    make(map[metricsKey]*subtreeStats),
+		paths:    make(map[metricsKey]int64),
+		words:    make(FoundWordsSet),
+		seen:     make(map[*ExplorationNode]struct{}),
+	}
+	root := m.subtree(tree, 0)
+	m.collectWords(tree)
+
+	metrics := Metrics{
+		OptimalPaths:    m.optimalPaths(tree, maxDepth),
+		CompletePaths:   root.leaves,
+		BranchingFactor: make([]float64, 0, len(root.states)),
+		FirstMoves:      len(tree),
+		TotalNodes:      root.nodes,
+		UniqueWords:     len(m.words),
+	}
+	for d, states := range root.states {
+		if root.moves[d] == 0 {
+			break
+		}
+		metrics.BranchingFactor = append(metrics.BranchingFactor, float64(root.moves[d])/float64(states))
+	}
+	if root.statesBeforeLimit > 0 {
+		metrics.DeadEndRatio = float64(root.deadEnds) / float64(root.statesBeforeLimit)
+	}
+	if root.nodes > 0 {
+		metrics.TrapRatio = float64(root.traps) / float64(root.nodes)
+	}
+	return metrics
+}
+
+// metricsKey identifies a list of moves by its first element and the depth it is reached at.
+type metricsKey struct {
+	first *ExplorationNode
+	depth int
+}
+
+// subtreeStats are the counts for the subtree below one state, with states and moves indexed
+// by depth relative to that state.
+type subtreeStats struct {
+	nodes             int64
+	leaves            int64
+	traps             int64
+	states            []int64
+	moves             []int64
+	deadEnds          int64
+	statesBeforeLimit int64
+}
+
+type metricsWalker struct {
+	maxTurns int
+	stats    map[metricsKey]*subtreeStats
+	paths    map[metricsKey]int64
+	words    FoundWordsSet
+	seen     map[*ExplorationNode]struct{}
+}
+
+// subtree returns the counts for the state at depth whose moves are nodes.
+func (m *metricsWalker) subtree(nodes []ExplorationNode, depth int) *subtreeStats {
+	if len(nodes) == 0 {
+		s := &subtreeStats{leaves: 1, states: []int64{1}, moves: []int64{0}}
+		if depth < m.maxTurns {
+			s.deadEnds, s.statesBeforeLimit = 1, 1
+		}
+		return s
+	}
+	key := metricsKey{first: &nodes[0], depth: depth}
+	if s, ok := m.stats[key]; ok {
+		return s
+	}
+
+	s := &subtreeStats{states: []int64{1}, moves: []int64{int64(len(nodes))}, statesBeforeLimit: 1}
+	best := 0
+	for i := range nodes {
+		best = max(best, nodes[i].MaxDepthReached)
+	}
+	for i := range nodes {
+		child := m.subtree(nodes[i].NextMoves, depth+1)
+		s.nodes += 1 + child.nodes
+		s.leaves += child.leaves
+		s.traps += child.traps
+		if nodes[i].MaxDepthReached < best {
+			s.traps++
+		}
+		s.deadEnds += child.deadEnds
+		s.statesBeforeLimit += child.statesBeforeLimit
+		for d := range child.states {
+			if d+1 == len(s.states) {
+				s.states = append(s.states, 0)
+				s.moves = append(s.moves, 0)
+			}
+			s.states[d+1] += child.states[d]
+			s.moves[d+1] += child.moves[d]
+		}
+	}
+	m.stats[key] = s
+	return s
+}
+
+// optimalPaths counts the move sequences through nodes that make exactly remaining moves.
+func (m *metricsWalker) optimalPaths(nodes []ExplorationNode, remaining int) int64 {
+	if remaining == 0 {
+		return 1
+	}
+	if len(nodes) == 0 {
+		return 0
+	}
+	key := metricsKey{first: &nodes[0], depth: remaining}
+	if n, ok := m.paths[key]; ok {
+		return n
+	}
+	var n int64
+	for i := range nodes {
+		if 1+nodes[i].MaxDepthReached == remaining {
+			n += m.optimalPaths(nodes[i].NextMoves, remaining-1)
+		}
+	}
+	m.paths[key] = n
+	return n
+}
+
+// collectWords is CollectAllWords for trees with shared subtrees.
+func (m *metricsWalker) collectWords(nodes []ExplorationNode) {
+	if len(nodes) == 0 {
+		return
+	}
+	if _, ok := m.seen[&nodes[0]]; ok {
+		return
+	}
+	m.seen[&nodes[0]] = struct{}{}
+	for i := range nodes {
+		for _, word := range nodes[i].WordsFormed {
+			m.words[word] = struct{}{}
+		}
+		m.collectWords(nodes[i].NextMoves)
+	}
+}
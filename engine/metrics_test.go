@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// node returns an exploration node for a move forming words, followed by next.
+func node(words []string, maxDepth int, next ...ExplorationNode) ExplorationNode {
+	return ExplorationNode{Move: &MoveOutput{}, WordsFormed: words, MaxDepthReached: maxDepth, NextMoves: next}
+}
+
+// metricsTree is a level explored up to 3 turns that lasts 2 moves at most. Three of its four
+// games are optimal, and its third first move is a trap that ends the game after one move.
+func metricsTree() []ExplorationNode {
+	return []ExplorationNode{
+		node([]string{"ant"}, 1, node([]string{"sea"}, 0)),
+		node([]string{"tea"}, 1, node([]string{"sea"}, 0), node([]string{"sun"}, 0)),
+		node([]string{"ten"}, 0),
+	}
+}
+
+func TestComputeMetrics(t *testing.T) {
+	got := ComputeMetrics(metricsTree(), 2, 3)
+	want := Metrics{
+		OptimalPaths:    3,
+		CompletePaths:   4,
+		BranchingFactor: []float64{3, 1},
+		// The root and the three states after one move are not dead ends, the four games end in one.
+		DeadEndRatio: 4.0 / 7,
+		TrapRatio:    1.0 / 6,
+		FirstMoves:   3,
+		TotalNodes:   6,
+		UniqueWords:  5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeMetrics =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// States at the turn limit are not dead ends.
+	if got := ComputeMetrics(metricsTree(), 2, 2); got.DeadEndRatio != 1.0/4 {
+		t.Errorf("DeadEndRatio at the turn limit = %v, want 1/4", got.DeadEndRatio)
+	}
+}
+
+// TestComputeMetricsSharedSubtrees checks that subtrees shared between parents, as Solve
+// returns them, count as often as copies of them do.
+func TestComputeMetricsSharedSubtrees(t *testing.T) {
+	tree := metricsTree()
+	shared := node([]string{"eat"}, 1)
+	shared.NextMoves = tree[1].NextMoves
+	tree = append(tree, shared)
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var copied []ExplorationNode
+	if err := json.Unmarshal(data, &copied); err != nil {
+		t.Fatal(err)
+	}
+
+	got, want := ComputeMetrics(tree, 2, 3), ComputeMetrics(copied, 2, 3)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shared subtrees: %+v, copied: %+v", got, want)
+	}
+	if got.OptimalPaths != 5 || got.CompletePaths != 6 || got.TotalNodes != 9 {
+		t.Errorf("ComputeMetrics = %+v, want 5 optimal of 6 paths and 9 nodes", got)
+	}
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SchemaVersion is the version of the level file schema written by this package.
+//
+// Version 1 files have no schemaVersion field and may carry the generation seed and difficulty
+// as top-level fields. Version 2 adds schemaVersion and moves the seed and difficulty into
+// provenance, alongside the rest of the record of how the level was produced.
+const SchemaVersion = 2
+
+// Provenance records how a level was produced. Levels migrated from older schema versions only
+// carry what the old file recorded.
+type Provenance struct {
+	Generator    *BuildInfo        `json:"generator,omitempty"`
+	GeneratedAt  *time.Time        `json:"generatedAt,omitempty"`
+	Dictionaries map[string]string `json:"dictionaries,omitempty"`
+	Config       json.RawMessage   `json:"config,omitempty"`
+	Seed         uint64            `json:"seed,omitempty,string"`
+	Difficulty   string            `json:"difficulty,omitempty"`
+	// GridSeed seeds the random source the initial grid was drawn from, for levels whose grid
+	// does not follow from Seed, the difficulty and the date alone, such as classified runs.
+	GridSeed uint64 `json:"gridSeed,omitempty,string"`
+}
+
+// BuildInfo identifies the build of the program that generated a level.
+type BuildInfo struct {
+	Path      string `json:"path"`
+	Version   string `json:"version,omitempty"`
+	GoVersion string `json:"goVersion,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// LevelHeader holds the fields needed to decide how to decode a level file.
+type LevelHeader struct {
+	Format        string `json:"format"`
+	SchemaVersion int    `json:"schemaVersion"`
+}
+
+// ReadLevelHeader decodes the layout and schema version of a level file. Files without a
+// schemaVersion field are reported as version 1.
+func ReadLevelHeader(data []byte) (LevelHeader, error) {
+	var header LevelHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return header, err
+	}
+	if header.SchemaVersion == 0 {
+		header.SchemaVersion = 1
+	}
+	return header, nil
+}
+
+// ParseLevel decodes a level file in either the tree or the DAG layout and returns it in the
+// tree layout, upgraded to the current SchemaVersion.
+func ParseLevel(data []byte) (*FullExplorationOutput, error) {
+	header, err := ReadLevelHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if header.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("schema version %d is newer than the supported version %d", header.SchemaVersion, SchemaVersion)
+	}
+
+	var level *FullExplorationOutput
+	switch header.Format {
+	case "":
+		level = &FullExplorationOutput{}
+		if err := json.Unmarshal(data, level); err != nil {
+			return nil, err
+		}
+	case DagFormat:
+		var dag DagExplorationOutput
+		if err := json.Unmarshal(data, &dag); err != nil {
+			return nil, err
+		}
+		if level, err = dag.ToTree(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown level format %q", header.Format)
+	}
+
+	if header.SchemaVersion < 2 {
+		if err := migrateV1(data, &level.LevelInfo); err != nil {
+			return nil, err
+		}
+	}
+	level.SchemaVersion = SchemaVersion
+	return level, nil
+}
+
+// migrateV1 moves the top-level seed and difficulty of a version 1 file into provenance.
+func migrateV1(data []byte, info *LevelInfo) error {
+	var v1 struct {
+		Seed       uint64 `json:"seed,omitempty,string"`
+		Difficulty string `json:"difficulty,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return err
+	}
+	if v1.Seed == 0 && v1.Difficulty == "" {
+		return nil
+	}
+	if info.Provenance == nil {
+		info.Provenance = &Provenance{}
+	}
+	info.Provenance.Seed = v1.Seed
+	info.Provenance.Difficulty = v1.Difficulty
+	return nil
+}
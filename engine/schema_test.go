@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestParseLevelMigratesV1(t *testing.T) {
+	data, err := os.ReadFile("testdata/level_v1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := ReadLevelHeader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.SchemaVersion != 1 || header.Format != "" {
+		t.Errorf("ReadLevelHeader = %+v, want a version 1 tree", header)
+	}
+
+	level, err := ParseLevel(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.SchemaVersion != SchemaVersion {
+		t.Errorf("schemaVersion = %d, want %d", level.SchemaVersion, SchemaVersion)
+	}
+	if p := level.Provenance; p == nil || p.Seed != 8935141660703064064 || p.Difficulty != "hard" {
+		t.Errorf("provenance = %+v, want the seed and difficulty of the v1 file", p)
+	}
+	if len(level.ExplorationTree) != 1 || level.MaxDepthReached != 2 {
+		t.Errorf("the exploration tree was not kept: %+v", level.ExplorationTree)
+	}
+
+	// The DAG layout carries the same fields and is migrated the same way.
+	dag := level.ToDag()
+	dag.SchemaVersion, dag.Provenance = 0, nil
+	dagData, err := json.Marshal(dag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dagLevel, err := ParseLevel(dagData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dagLevel.SchemaVersion != SchemaVersion || dagLevel.Provenance != nil {
+		t.Errorf("DAG level: schemaVersion %d, provenance %+v, want %d and none", dagLevel.SchemaVersion, dagLevel.Provenance, SchemaVersion)
+	}
+}
+
+func TestParseLevelRejectsNewerVersions(t *testing.T) {
+	for _, data := range []string{
+		`{"schemaVersion": 3, "initialGrid": [["a"]], "explorationTree": []}`,
+		`{"schemaVersion": 3, "format": "dag", "root": 0, "states": [null]}`,
+	} {
+		if _, err := ParseLevel([]byte(data)); err == nil {
+			t.Errorf("ParseLevel(%s) accepted a newer schema version", data)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Strategy decides which move a simulated player makes. Strategies only look at the moves on
+// offer and at most a few moves ahead of them, the way a person playing the level would.
+type Strategy interface {
+	// Name identifies the strategy, as accepted by ParseStrategy.
+	Name() string
+	// Choose returns the index of the move to play among moves, which is never empty.
+	Choose(rng *rand.Rand, moves []ExplorationNode) int
+}
+
+// RandomStrategy plays any move that forms a word.
+type RandomStrategy struct{}
+
+func (RandomStrategy) Name() string { return "random" }
+
+func (RandomStrategy) Choose(rng *rand.Rand, moves []ExplorationNode) int {
+	return rng.IntN(len(moves))
+}
+
+// GreedyStrategy plays the move that forms the most words right away.
+type GreedyStrategy struct{}
+
+func (GreedyStrategy) Name() string { return "greedy" }
+
+func (GreedyStrategy) Choose(rng *rand.Rand, moves []ExplorationNode) int {
+	return chooseBest(rng, moves, func(a, b *ExplorationNode) int {
+		return cmp.Compare(len(a.WordsFormed), len(b.WordsFormed))
+	})
+}
+
+// LookaheadStrategy plays the move that keeps the game going longest within its next Depth
+// moves, preferring moves that form more words right away when several do.
+type LookaheadStrategy struct {
+	Depth int
+}
+
+func (s LookaheadStrategy) Name() string { return fmt.Sprintf("lookahead-%d", s.Depth) }
+
+func (s LookaheadStrategy) Choose(rng *rand.Rand, moves []ExplorationNode) int {
+	// The longest game after a move is a chain of 1+MaxDepthReached moves, and every prefix of it
+	// is a game too, so a player seeing Depth moves ahead can only tell chains apart up to Depth.
+	horizon := func(node *ExplorationNode) int {
+		return min(s.Depth, 1+node.MaxDepthReached)
+	}
+	return chooseBest(rng, moves, func(a, b *ExplorationNode) int {
+		return cmp.Or(cmp.Compare(horizon(a), horizon(b)), cmp.Compare(len(a.WordsFormed), len(b.WordsFormed)))
+	})
+}
+
+// chooseBest returns the index of the best move as ordered by compare, breaking ties at random.
+func chooseBest(rng *rand.Rand, moves []ExplorationNode, compare func(a, b *ExplorationNode) int) int {
+	best, ties := 0, 0
+	for i := 1; i < len(moves); i++ {
+		switch c := compare(&moves[i], &moves[best]); {
+		case c > 0:
+			best, ties = i, 0
+		case c == 0:
+			ties++
+			if rng.IntN(ties+1) == 0 {
+				best = i
+			}
+		}
+	}
+	return best
+}
+
+// ParseStrategy returns the strategy with the given name: random, greedy or lookahead-N.
+func ParseStrategy(name string) (Strategy, error) {
+	switch name {
+	case "random":
+		return RandomStrategy{}, nil
+	case "greedy":
+		return GreedyStrategy{}, nil
+	}
+	if depth, ok := strings.CutPrefix(name, "lookahead-"); ok {
+		n, err := strconv.Atoi(depth)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid lookahead depth %q", depth)
+		}
+		return LookaheadStrategy{Depth: n}, nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q", name)
+}
+
+// Simulation is the outcome of playing a level many times with one strategy.
+type Simulation struct {
+	Strategy string `json:"strategy"`
+	Games    int    `json:"games"`
+	// Depths counts the games by the number of moves they lasted: Depths[d] games ended after d
+	// moves. It runs up to the depth of the longest game played.
+	Depths []int `json:"depths"`
+	// MeanDepth is the average number of moves a game lasted.
+	MeanDepth float64 `json:"meanDepth"`
+	// OptimalRate is the fraction of games that reached the level's MaxDepthReached.
+	OptimalRate float64 `json:"optimalRate"`
+}
+
+// Simulate plays the level described by tree games times with strategy. A game ends when no
+// move forms a word or the turn limit of the exploration is reached.
+func Simulate(tree []ExplorationNode, maxDepth int, strategy Strategy, games int, rng *rand.Rand) Simulation {
+	sim := Simulation{Strategy: strategy.Name(), Games: games}
+	total, optimal := 0, 0
+	for range games {
+		depth := 0
+		for nodes := tree; len(nodes) > 0; depth++ {
+			nodes = nodes[strategy.Choose(rng, nodes)].NextMoves
+		}
+		for len(sim.Depths) <= depth {
+			sim.Depths = append(sim.Depths, 0)
+		}
+		sim.Depths[depth]++
+		total += depth
+		if depth >= maxDepth {
+			optimal++
+		}
+	}
+	if games > 0 {
+		sim.MeanDepth = float64(total) / float64(games)
+		sim.OptimalRate = float64(optimal) / float64(games)
+	}
+	return sim
+}
+
+// FindSimulation returns the simulation played with the named strategy, or nil if there is none.
+func FindSimulation(sims []Simulation, strategy string) *Simulation {
+	for i := range sims {
+		if sims[i].Strategy == strategy {
+			return &sims[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// simulationTree is a level that lasts 3 moves. The first move forming the most words is a
+// trap that ends the game, and only a player looking two moves ahead avoids it.
+func simulationTree() []ExplorationNode {
+	return []ExplorationNode{
+		node([]string{"ant", "tan"}, 0),
+		node([]string{"tea"}, 2, node([]string{"sea"}, 1, node([]string{"sun"}, 0))),
+		node([]string{"ten"}, 0),
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	tree := simulationTree()
+	const games = 300
+	simulate := func(strategy Strategy) Simulation {
+		return Simulate(tree, 3, strategy, games, rand.New(rand.NewPCG(7, 8)))
+	}
+
+	greedy := simulate(GreedyStrategy{})
+	if want := (Simulation{Strategy: "greedy", Games: games, Depths: []int{0, games}, MeanDepth: 1}); !reflect.DeepEqual(greedy, want) {
+		t.Errorf("greedy: %+v, want %+v", greedy, want)
+	}
+	lookahead := simulate(LookaheadStrategy{Depth: 2})
+	if want := (Simulation{Strategy: "lookahead-2", Games: games, Depths: []int{0, 0, 0, games}, MeanDepth: 3, OptimalRate: 1}); !reflect.DeepEqual(lookahead, want) {
+		t.Errorf("lookahead-2: %+v, want %+v", lookahead, want)
+	}
+	random := simulate(RandomStrategy{})
+	if random.Depths[1]+random.Depths[3] != games || random.OptimalRate == 0 || random.OptimalRate == 1 {
+		t.Errorf("random: %+v, want games of 1 and 3 moves", random)
+	}
+	if !(greedy.MeanDepth < random.MeanDepth && random.MeanDepth < lookahead.MeanDepth) {
+		t.Errorf("mean depths: greedy %v, random %v, lookahead-2 %v, want them increasing",
+			greedy.MeanDepth, random.MeanDepth, lookahead.MeanDepth)
+	}
+
+	// The same seed plays the same games.
+	for _, strategy := range []Strategy{RandomStrategy{}, GreedyStrategy{}, LookaheadStrategy{Depth: 1}, LookaheadStrategy{Depth: 2}} {
+		if first, second := simulate(strategy), simulate(strategy); !reflect.DeepEqual(first, second) {
+			t.Errorf("%s: %+v, then %+v with the same seed", strategy.Name(), first, second)
+		}
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range []string{"random", "greedy", "lookahead-1", "lookahead-3"} {
+		strategy, err := ParseStrategy(name)
+		if err != nil || strategy.Name() != name {
+			t.Errorf("ParseStrategy(%q) = %v, %v", name, strategy, err)
+		}
+	}
+	for _, name := range []string{"", "smart", "lookahead-", "lookahead-0", "lookahead-x"} {
+		if _, err := ParseStrategy(name); err == nil {
+			t.Errorf("ParseStrategy(%q) accepted an unknown strategy", name)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package engine implements the wordseq puzzle rules: applying swaps to a
+// letter grid, detecting newly formed words and exploring every move
+// sequence reachable from an initial grid.
+package engine
+
+import "fmt"
+
+// --- Core Data Structures ---
+type Grid [][]rune
+type JsonGrid [][]string
+type Coordinates struct {
+	Row int `json:"-"`
+	Col int `json:"-"`
+}
+type Move struct {
+	Cell1 Coordinates `json:"-"`
+	Cell2 Coordinates `json:"-"`
+}
+type FoundWordsSet map[string]struct{}
+type GameState struct {
+	Grid       Grid
+	FoundWords FoundWordsSet
+}
+type ExplorationCacheEntry struct {
+	Children []ExplorationNode
+	MaxDepth int
+}
+
+// Rules holds the game parameters that govern word detection and exploration.
+type Rules struct {
+	// WordLength is the exact length of a word to be considered valid.
+	WordLength int
+	// MaxTurns is the depth at which exploration stops.
+	MaxTurns int
+}
+
+// --- Structs for Nested JSON Output ---
+type MoveOutput struct {
+	From [2]int `json:"from"`
+	To   [2]int `json:"to"`
+}
+type ExplorationNode struct {
+	Move            *MoveOutput       `json:"move"`
+	WordsFormed     []string          `json:"wordsFormed"`
+	MaxDepthReached int               `json:"maxDepthReached"`
+	NextMoves       []ExplorationNode `json:"nextMoves,omitempty"`
+}
+type FullExplorationOutput struct {
+	LevelInfo
+	ExplorationTree []ExplorationNode `json:"explorationTree"`
+}
+
+// LevelInfo holds the fields shared by every level file layout.
+type LevelInfo struct {
+	SchemaVersion    int               `json:"schemaVersion"`
+	Language         string            `json:"language,omitempty"`
+	InitialGrid      JsonGrid          `json:"initialGrid"`
+	WordLength       int               `json:"wordLength"`
+	RequiredMinTurns int               `json:"requiredMinTurns"`
+	RequiredMaxTurns int               `json:"requiredMaxTurns"`
+	MaxDepthReached  int               `json:"maxDepthReached"`
+	Metrics          *Metrics          `json:"metrics,omitempty"`
+	Classification   *Classification   `json:"classification,omitempty"`
+	Simulations      []Simulation      `json:"simulations,omitempty"`
+	Stems            map[string]string `json:"stems,omitempty"`
+	Provenance       *Provenance       `json:"provenance,omitempty"`
+}
+
+func (m Move) String() string {
+	return fmt.Sprintf("Swap (%d, %d) <-> (%d, %d)", m.Cell1.Row, m.Cell1.Col, m.Cell2.Row, m.Cell2.Col)
+}
+
+func (m MoveOutput) String() string {
+	return fmt.Sprintf("(%d,%d)-(%d,%d)", m.From[0], m.From[1], m.To[0], m.To[1])
+}
+
+// Move converts the JSON form of a move back into a Move.
+func (m MoveOutput) Move() Move {
+	return Move{
+		Cell1: Coordinates{Row: m.From[0], Col: m.From[1]},
+		Cell2: Coordinates{Row: m.To[0], Col: m.To[1]},
+	}
+}
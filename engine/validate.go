@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LevelRule is a named check that a stored level file must satisfy.
+type LevelRule struct {
+	Name  string
+	Check func(level *FullExplorationOutput, dict Dictionary) error
+}
+
+// NoInitialWords rejects levels whose initial grid already contains a word in any row or column.
+var NoInitialWords = LevelRule{
+	Name: "no-initial-words",
+	Check: func(level *FullExplorationOutput, dict Dictionary) error {
+		rules := Rules{WordLength: level.WordLength, MaxTurns: level.RequiredMaxTurns}
+		grid, err := ConvertJsonGridToGrid(level.InitialGrid)
+		if err != nil {
+			return err
+		}
+		words := FindAllWords(rules, grid, dict)
+		if len(words) > 0 {
+			return fmt.Errorf("initial grid already contains: %s", strings.Join(words, ", "))
+		}
+		return nil
+	},
+}
+
+// ReplayMoves replays every move of the exploration tree from the initial grid. It checks that
+// each move is a legal swap, that wordsFormed matches the words FindNewWords detects after it and
+// that every maxDepthReached agrees with the subtree below it. Each problem is reported with the
+// path of moves leading to the offending node.
+var ReplayMoves = LevelRule{
+	Name: "replay-moves",
+	Check: func(level *FullExplorationOutput, dict Dictionary) error {
+		rules := Rules{WordLength: level.WordLength, MaxTurns: level.RequiredMaxTurns}
+		grid, err := ConvertJsonGridToGrid(level.InitialGrid)
+		if err != nil {
+			return err
+		}
+		initialState := GameState{Grid: grid, FoundWords: make(FoundWordsSet)}
+		errs := replayNodes(rules, dict, initialState, level.ExplorationTree, "root", 0)
+		if depth := subtreeDepth(level.ExplorationTree); depth != level.MaxDepthReached {
+			errs = append(errs, fmt.Errorf("root: maxDepthReached is %d, tree reaches %d", level.MaxDepthReached, depth))
+		}
+		if level.MaxDepthReached < level.RequiredMinTurns || level.MaxDepthReached > level.RequiredMaxTurns {
+			errs = append(errs, fmt.Errorf("root: maxDepthReached %d is outside the required %d-%d turns",
+				level.MaxDepthReached, level.RequiredMinTurns, level.RequiredMaxTurns))
+		}
+		return errors.Join(errs...)
+	},
+}
+
+func replayNodes(rules Rules, dict Dictionary, state GameState, nodes []ExplorationNode, path string, depth int) []error {
+	var errs []error
+	if len(nodes) > 0 && depth >= rules.MaxTurns {
+		errs = append(errs, fmt.Errorf("%s: has moves beyond requiredMaxTurns %d", path, rules.MaxTurns))
+	}
+	for _, node := range nodes {
+		if node.Move == nil {
+			errs = append(errs, fmt.Errorf("%s: node has no move", path))
+			continue
+		}
+		nodePath := path + " > " + node.Move.String()
+		move := node.Move.Move()
+		if !isAdjacent(move) {
+			errs = append(errs, fmt.Errorf("%s: cells are not adjacent", nodePath))
+			continue
+		}
+		nextGrid := ApplyMove(state.Grid, move)
+		if nextGrid == nil {
+			errs = append(errs, fmt.Errorf("%s: move is out of bounds", nodePath))
+			continue
+		}
+		words := FindNewWords(rules, nextGrid, move, dict, state.FoundWords)
+		if !slices.Equal(words, node.WordsFormed) {
+			errs = append(errs, fmt.Errorf("%s: wordsFormed is [%s], move forms [%s]",
+				nodePath, strings.Join(node.WordsFormed, ","), strings.Join(words, ",")))
+		}
+		if depth := subtreeDepth(node.NextMoves); depth != node.MaxDepthReached {
+			errs = append(errs, fmt.Errorf("%s: maxDepthReached is %d, subtree reaches %d", nodePath, node.MaxDepthReached, depth))
+		}
+		nextFound := CopyFoundWords(state.FoundWords)
+		for _, word := range words {
+			nextFound[word] = struct{}{}
+		}
+		nextState := GameState{Grid: nextGrid, FoundWords: nextFound}
+		errs = append(errs, replayNodes(rules, dict, nextState, node.NextMoves, nodePath, depth+1)...)
+	}
+	return errs
+}
+
+// subtreeDepth returns the number of moves on the longest path through nodes, according to the
+// maxDepthReached values stored on them.
+func subtreeDepth(nodes []ExplorationNode) int {
+	depth := 0
+	for _, node := range nodes {
+		depth = max(depth, 1+node.MaxDepthReached)
+	}
+	return depth
+}
+
+func isAdjacent(move Move) bool {
+	dr := move.Cell1.Row - move.Cell2.Row
+	dc := move.Cell1.Col - move.Cell2.Col
+	return dr*dr+dc*dc == 1
+}
+
+// DefaultLevelRules are the rules applied to level files when none are specified.
+var DefaultLevelRules = []LevelRule{NoInitialWords, ReplayMoves}
+
+// ValidateLevel applies every rule to the level and returns the violations, each prefixed
+// with the name of the rule that reported it. Rules that report several problems at once
+// by joining errors have each problem listed separately.
+func ValidateLevel(level *FullExplorationOutput, dict Dictionary, rules ...LevelRule) []error {
+	var errs []error
+	for _, rule := range rules {
+		err := rule.Check(level, dict)
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				errs = append(errs, fmt.Errorf("%s: %w", rule.Name, e))
+			}
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", rule.Name, err))
+	}
+	return errs
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// validLevel is a level of a 2x3 grid, solved with the words tea and net.
+const validLevel = `{
+	"schemaVersion": 2,
+	"initialGrid": [["t", "a", "e"], ["n", "x", "t"]],
+	"wordLength": 3,
+	"requiredMinTurns": 2,
+	"requiredMaxTurns": 3,
+	"maxDepthReached": 2,
+	"explorationTree": [{
+		"move": {"from": [0, 1], "to": [0, 2]},
+		"wordsFormed": ["tea"],
+		"maxDepthReached": 1,
+		"nextMoves": [{
+			"move": {"from": [0, 1], "to": [1, 1]},
+			"wordsFormed": ["net"],
+			"maxDepthReached": 0
+		}]
+	}]
+}`
+
+func TestValidateLevel(t *testing.T) {
+	dict := NewTrie([]string{"tea", "ten", "net", "eat"})
+	tests := []struct {
+		name    string
+		corrupt func(level *FullExplorationOutput)
+		want    []string
+	}{
+		{
+			name:    "valid",
+			corrupt: func(level *FullExplorationOutput) {},
+		},
+		{
+			name:    "initial word",
+			corrupt: func(level *FullExplorationOutput) { level.InitialGrid[1] = []string{"n", "e", "t"} },
+			want:    []string{"no-initial-words: initial grid already contains: net"},
+		},
+		{
+			name:    "cells not adjacent",
+			corrupt: func(level *FullExplorationOutput) { level.ExplorationTree[0].Move.To = [2]int{1, 2} },
+			want:    []string{"replay-moves: root > (0,1)-(1,2): cells are not adjacent"},
+		},
+		{
+			name:    "move out of bounds",
+			corrupt: func(level *FullExplorationOutput) { level.ExplorationTree[0].Move.To = [2]int{-1, 1} },
+			want:    []string{"replay-moves: root > (0,1)-(-1,1): move is out of bounds"},
+		},
+		{
+			name:    "missing move",
+			corrupt: func(level *FullExplorationOutput) { level.ExplorationTree[0].NextMoves[0].Move = nil },
+			want:    []string{"replay-moves: root > (0,1)-(0,2): node has no move"},
+		},
+		{
+			name: "wrong words formed",
+			corrupt: func(level *FullExplorationOutput) {
+				level.ExplorationTree[0].NextMoves[0].WordsFormed = []string{"ten"}
+			},
+			want: []string{"replay-moves: root > (0,1)-(0,2) > (0,1)-(1,1): wordsFormed is [ten], move forms [net]"},
+		},
+		{
+			name:    "node depth",
+			corrupt: func(level *FullExplorationOutput) { level.ExplorationTree[0].MaxDepthReached = 2 },
+			// The root is checked against the depth stored on its children, so it disagrees too.
+			want: []string{
+				"replay-moves: root > (0,1)-(0,2): maxDepthReached is 2, subtree reaches 1",
+				"replay-moves: root: maxDepthReached is 2, tree reaches 3",
+			},
+		},
+		{
+			name:    "root depth",
+			corrupt: func(level *FullExplorationOutput) { level.MaxDepthReached = 3 },
+			want:    []string{"replay-moves: root: maxDepthReached is 3, tree reaches 2"},
+		},
+		{
+			name:    "too few turns",
+			corrupt: func(level *FullExplorationOutput) { level.RequiredMinTurns = 3 },
+			want:    []string{"replay-moves: root: maxDepthReached 2 is outside the required 3-3 turns"},
+		},
+		{
+			name: "too many turns",
+			corrupt: func(level *FullExplorationOutput) {
+				level.RequiredMinTurns, level.RequiredMaxTurns = 1, 1
+			},
+			want: []string{
+				"replay-moves: root > (0,1)-(0,2): has moves beyond requiredMaxTurns 1",
+				"replay-moves: root: maxDepthReached 2 is outside the required 1-1 turns",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var level FullExplorationOutput
+			if err := json.Unmarshal([]byte(validLevel), &level); err != nil {
+				t.Fatal(err)
+			}
+			tt.corrupt(&level)
+			var got []string
+			for _, err := range ValidateLevel(&level, dict, DefaultLevelRules...) {
+				got = append(got, err.Error())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateLevel =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
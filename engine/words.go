@@ -0,0 +1,108 @@
+package engine
+
+import "sort"
+
+// FindNewWords returns the sorted dictionary words in the rows and columns touched by move
+// that are not already in foundWordsBeforeMove.
+func FindNewWords(rules Rules, newGrid Grid, move Move, dict Dictionary, foundWordsBeforeMove FoundWordsSet) []string {
+	if newGrid == nil {
+		return nil
+	}
+	rows := len(newGrid)
+	if rows == 0 || len(newGrid[0]) == 0 {
+		return []string{}
+	}
+	cols := len(newGrid[0])
+	c1, c2 := move.Cell1, move.Cell2
+	newlyFound := make(map[string]struct{})
+	// Lines are scanned as runes, so that a window of WordLength cells spells WordLength letters
+	// whatever their encoded size.
+	scanLine := func(line []rune) {
+		for start := 0; start+rules.WordLength <= len(line); start++ {
+			sub := string(line[start : start+rules.WordLength])
+			if _, alreadyFound := foundWordsBeforeMove[sub]; !alreadyFound && dict.Contains(sub) {
+				newlyFound[sub] = struct{}{}
+			}
+		}
+	}
+	rowsToCheck := map[int]struct{}{c1.Row: {}}
+	if c1.Row != c2.Row {
+		rowsToCheck[c2.Row] = struct{}{}
+	}
+	for r := range rowsToCheck {
+		if r < 0 || r >= rows {
+			continue
+		}
+		scanLine(newGrid[r])
+	}
+	colsToCheck := map[int]struct{}{c1.Col: {}}
+	if c1.Col != c2.Col {
+		colsToCheck[c2.Col] = struct{}{}
+	}
+	column := make([]rune, rows)
+	for c := range colsToCheck {
+		if c < 0 || c >= cols {
+			continue
+		}
+		validCol := true
+		for r := range rows {
+			if c >= len(newGrid[r]) {
+				validCol = false
+				break
+			}
+			column[r] = newGrid[r][c]
+		}
+		if validCol {
+			scanLine(column)
+		}
+	}
+	result := make([]string, 0, len(newlyFound))
+	for word := range newlyFound {
+		result = append(result, word)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// FindAllWords returns the sorted dictionary words found anywhere in the rows and columns of grid.
+// A valid initial grid contains none.
+func FindAllWords(rules Rules, grid Grid, dict Dictionary) []string {
+	rows := len(grid)
+	if rows == 0 || len(grid[0]) == 0 {
+		return []string{}
+	}
+	cols := len(grid[0])
+	found := make(map[string]struct{})
+	scanLine := func(line []rune) {
+		for start := 0; start+rules.WordLength <= len(line); start++ {
+			sub := string(line[start : start+rules.WordLength])
+			if dict.Contains(sub) {
+				found[sub] = struct{}{}
+			}
+		}
+	}
+	for r := range rows {
+		scanLine(grid[r])
+	}
+	// Columns that a shorter row cuts off are skipped, as in FindNewWords.
+	column := make([]rune, rows)
+	for c := range cols {
+		validCol := true
+		for r := range rows {
+			if c >= len(grid[r]) {
+				validCol = false
+				break
+			}
+			column[r] = grid[r][c]
+		}
+		if validCol {
+			scanLine(column)
+		}
+	}
+	result := make([]string, 0, len(found))
+	for word := range found {
+		result = append(result, word)
+	}
+	sort.Strings(result)
+	return result
+}
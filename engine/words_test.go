@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindWordsRaggedGrid(t *testing.T) {
+	dict := NewTrie([]string{"tea", "ten"})
+	rules := Rules{WordLength: 3}
+	// The last row is short, so the third column spells tea only if it is cut off there. Both
+	// functions skip it and still scan the first column, which spells ten.
+	grid := Grid{
+		[]rune("txt"),
+		[]rune("exe"),
+		[]rune("nxa"),
+		[]rune("xx"),
+	}
+	want := []string{"ten"}
+	if got := FindAllWords(rules, grid, dict); !slices.Equal(got, want) {
+		t.Errorf("FindAllWords = %v, want %v", got, want)
+	}
+	move := Move{Cell1: Coordinates{Row: 0, Col: 0}, Cell2: Coordinates{Row: 0, Col: 2}}
+	if got := FindNewWords(rules, grid, move, dict, FoundWordsSet{}); !slices.Equal(got, want) {
+		t.Errorf("FindNewWords = %v, want %v", got, want)
+	}
+}
@@ -9,9 +9,12 @@ import {
     findWordCoordinates,
     CellCoordinates,
     GameData,
+    DagGameData,
     HistoryEntry,
     ExplorationNodeData,
     DifficultyLevel,
+    levelLanguage,
+    toGameData,
 } from '../utils/gameHelpers';
 import { GameLogic, CoreGameState } from '../core/gameLogic';
 import * as storage from '../core/storage'; // Import the new storage module
@@ -207,17 +210,19 @@ export const gameHooks = () => {
 
             try {
                 const basePath = '';
-                const response = await fetch(`${basePath}/levels/${diff}/${getDataFilePath(date)}`);
+                const response = await fetch(`${basePath}/levels/${levelLanguage}/${diff}/${getDataFilePath(date)}`);
                 if (!response.ok) {
                     if (response.status === 404) throw new Error(`Today's ${diff} level is not available yet. Please check back later!`);
                     throw new Error(`Failed to fetch ${diff} level for ${getFormattedDate(date)} (HTTP ${response.status})`);
                 }
-                const fetchedGameData: GameData = await response.json();
+                const fetchedLevel: GameData | DagGameData = await response.json();
+                const fetchedGameData = toGameData(fetchedLevel);
                 if (!fetchedGameData || !fetchedGameData.initialGrid || !Array.isArray(fetchedGameData.initialGrid)) {
                     throw new Error(`Level data for ${diff} is corrupted.`);
                 }
 
-                const fetchedGameDataString = JSON.stringify(fetchedGameData);
+                // Hash the file as fetched: an expanded DAG level would stringify to the whole tree.
+                const fetchedGameDataString = JSON.stringify(fetchedLevel);
                 const currentJsonFileHash = storage.simpleHash(fetchedGameDataString);
                 
                 const savedProgressForLevel = storage.loadInProgressState(date, diff, currentJsonFileHash);
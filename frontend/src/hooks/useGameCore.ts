@@ -1,7 +1,7 @@
 // src/hooks/useGameCore.ts
 import { useState, useEffect, useCallback, useRef, useMemo } from 'react';
 import {
-    GameData, CoreGameState, DifficultyLevel, CellCoordinates, HistoryEntry,
+    GameData, DagGameData, CoreGameState, DifficultyLevel, CellCoordinates, HistoryEntry,
     SwapResult, UndoResult, GameMove // Ensure GameMove is defined if used by GameLogic
 } from '../types/gameTypes';
 // Import the actual GameLogic class
@@ -10,7 +10,9 @@ import { GameLogic } from '../core/gameLogic';
 import {
     getDataFilePath,
     getFormattedDate,
-    findLongestWordChain
+    findLongestWordChain,
+    levelLanguage,
+    toGameData
 } from '../utils/gameHelpers';
 // Import specific functions from the actual storage module
 import {
@@ -123,7 +125,7 @@ export const useGameCore = (
 
             try {
                 const basePath = '';
-                const filePath = `${basePath}/levels/${diff}/${getDataFilePath(date)}`;
+                const filePath = `${basePath}/levels/${levelLanguage}/${diff}/${getDataFilePath(date)}`;
                 const response = await fetch(`${filePath}?v=${Date.now()}`); 
                 console.log(`${logPrefix} Fetched from ${filePath}, status: ${response.status}`);
 
@@ -136,13 +138,15 @@ export const useGameCore = (
                     if (response.status === 404) throw new Error(`Today's ${diff} level is not available yet. Please check back later! (Path: ${filePath})`);
                     throw new Error(`Failed to fetch ${diff} level for ${getFormattedDate(date)} (HTTP ${response.status}, Path: ${filePath})`);
                 }
-                const fetchedGameData: GameData = await response.json();
+                const fetchedLevel: GameData | DagGameData = await response.json();
+                const fetchedGameData = toGameData(fetchedLevel);
                 console.log(`${logPrefix} Fetched GameData.`); 
 
                 if (loadOperationIdRef.current !== currentLoadId) { /* ... */ return; }
                 if (!fetchedGameData || !fetchedGameData.initialGrid || !Array.isArray(fetchedGameData.initialGrid)) { /* ... */ throw new Error(`Level data for ${diff} is corrupted.`); }
 
-                const fetchedGameDataString = JSON.stringify(fetchedGameData);
+                // Hash the file as fetched: an expanded DAG level would stringify to the whole tree.
+                const fetchedGameDataString = JSON.stringify(fetchedLevel);
                 const currentJsonFileHash = simpleHash(fetchedGameDataString);
                 const savedProgressForLevel = loadInProgressState(date, diff, currentJsonFileHash);
                 
@@ -36,6 +36,21 @@ export interface GameData {
     explorationTree: ExplorationNodeData[]; // Root nodes of the exploration tree
 }
 
+// A level in the DAG layout (`wordseq generate --format=dag`). Every distinct list of next moves
+// is stored once in states and referred to by its index; states[0] is the empty list.
+export interface DagMove {
+    move?: GameMove;
+    wordsFormed: string[];
+    maxDepthReached: number;
+    next?: number; // Index of the state holding the next moves, 0 or unset when the game ends
+}
+
+export interface DagGameData extends Omit<GameData, 'explorationTree'> {
+    format: 'dag';
+    root: number; // Index of the state holding the first moves
+    states: DagMove[][];
+}
+
 export interface HistoryEntry {
     grid: string[][]; // State of the grid after the move
     currentPossibleMoves: ExplorationNodeData[]; // Possible moves from this state (can be complex if not used)
@@ -5,6 +5,8 @@ import type {
     GameMove,
     ExplorationNodeData,
     GameData,
+    DagGameData,
+    DagMove,
     HistoryEntry,
     AnimationState,
     InitialGameStateUI // Imported for getInitialGameState
@@ -18,6 +20,8 @@ export type {
     GameMove,
     ExplorationNodeData,
     GameData,
+    DagGameData,
+    DagMove,
     HistoryEntry,
     AnimationState,
     InitialGameStateUI
@@ -25,6 +29,9 @@ export type {
 
 export const difficulties: DifficultyLevel[] = ['normal', 'hard', 'impossible'];
 
+/** Language of the levels to load, as the directory under public/levels. */
+export const levelLanguage = 'en';
+
 /**
  * Formats a date into a user-friendly string.
  * Example: "Wednesday, May 7, 2025"
@@ -89,6 +96,39 @@ export const getDataFilePath = (date: Date): string => {
     return `${year}/${month}/${day}.json`;
 };
 
+/**
+ * Converts a fetched level file into GameData. Levels in the DAG layout are expanded into the
+ * exploration tree the game plays on; states reached along several paths become shared subtrees,
+ * so the expanded tree takes no more memory than the DAG.
+ * @param level The parsed level file, in the tree or the DAG layout.
+ * @returns The level with its exploration tree.
+ * @throws If a DAG level refers to a missing state or its states form a cycle.
+ */
+export const toGameData = (level: GameData | DagGameData): GameData => {
+    if (!level || !('states' in level)) return level;
+    const { format, root, states, ...levelInfo } = level;
+    if (format !== 'dag') throw new Error(`Unknown level format ${format}.`);
+    const expanded = new Map<number, ExplorationNodeData[]>();
+    const expanding = new Set<number>();
+    const expand = (id: number): ExplorationNodeData[] => {
+        const cached = expanded.get(id);
+        if (cached) return cached;
+        if (!Number.isInteger(id) || id < 0 || id >= states.length) throw new Error(`Level state ${id} does not exist.`);
+        if (expanding.has(id)) throw new Error(`Level state ${id} is part of a cycle.`);
+        expanding.add(id);
+        const nodes = (states[id] ?? []).map((move: DagMove): ExplorationNodeData => ({
+            move: move.move,
+            wordsFormed: move.wordsFormed,
+            maxDepthReached: move.maxDepthReached,
+            nextMoves: move.next ? expand(move.next) : [],
+        }));
+        expanding.delete(id);
+        expanded.set(id, nodes);
+        return nodes;
+    };
+    return { ...levelInfo, explorationTree: expand(root) };
+};
+
 /**
  * Finds the longest chain of words from exploration nodes, optionally guided by player history.
  * @param nodes Array of root ExplorationNodeData.
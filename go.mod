@@ -2,4 +2,8 @@ module github.com/sudorandom/wordchain
 
 go 1.24.2
 
-require github.com/alecthomas/kong v1.10.0
+require (
+	github.com/alecthomas/kong v1.10.0
+	golang.org/x/text v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,232 @@
+// Package hunspell reads Hunspell dictionaries (.dic word stems and .aff affix rules) and expands
+// them into the full list of words they describe, like Hunspell's unmunch tool.
+package hunspell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Affixes holds the rules of an affix file that matter for expanding stems.
+type Affixes struct {
+	// FlagType is how flags are written: "char" (the default), "long", "num" or "UTF-8".
+	FlagType string
+	// Classes maps each flag to the affix class it names.
+	Classes map[string]*AffixClass
+	// NeedAffix flags stems that are not words on their own, only with an affix.
+	NeedAffix string
+	// OnlyInCompound flags stems that only appear inside compounds.
+	OnlyInCompound string
+	// Forbidden flags words that must not be produced.
+	Forbidden string
+}
+
+// AffixClass is a set of affix rules sharing a flag, such as the plural suffixes.
+type AffixClass struct {
+	Flag   string
+	Prefix bool
+	// CrossProduct allows the class to combine with an affix of the other kind.
+	CrossProduct bool
+	Rules        []AffixRule
+}
+
+// AffixRule turns a stem into a new word by removing Strip from one end and adding Add there.
+type AffixRule struct {
+	Strip string
+	Add   string
+	// Flags are the continuation flags of the derived word, which allow further affixes.
+	Flags     []string
+	Condition Condition
+}
+
+// Condition is a parsed affix condition: a sequence of letters, bracketed sets of letters and
+// dots, matched against the start of a stem for prefixes and its end for suffixes.
+type Condition []charClass
+
+type charClass struct {
+	letters string
+	negate  bool
+	any     bool
+}
+
+func (c charClass) matches(r rune) bool {
+	if c.any {
+		return true
+	}
+	return strings.ContainsRune(c.letters, r) != c.negate
+}
+
+// ParseCondition parses an affix condition such as "[^aeiou]y".
+func ParseCondition(s string) (Condition, error) {
+	if s == "." {
+		return nil, nil
+	}
+	var cond Condition
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			cond = append(cond, charClass{any: true})
+			s = s[1:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' in condition %q", s)
+			}
+			class := charClass{letters: s[1:end]}
+			if strings.HasPrefix(class.letters, "^") {
+				class.letters, class.negate = class.letters[1:], true
+			}
+			cond = append(cond, class)
+			s = s[end+1:]
+		default:
+			r, size := utf8.DecodeRuneInString(s)
+			cond = append(cond, charClass{letters: string(r)})
+			s = s[size:]
+		}
+	}
+	return cond, nil
+}
+
+// matchesStart reports whether the condition matches the first letters of word.
+func (c Condition) matchesStart(word []rune) bool {
+	if len(c) > len(word) {
+		return false
+	}
+	for i, class := range c {
+		if !class.matches(word[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesEnd reports whether the condition matches the last letters of word.
+func (c Condition) matchesEnd(word []rune) bool {
+	if len(c) > len(word) {
+		return false
+	}
+	offset := len(word) - len(c)
+	for i, class := range c {
+		if !class.matches(word[offset+i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAffixes reads an affix file. Only UTF-8 encoded files are supported. Directives that
+// only concern spelling suggestions or compounding are skipped.
+func ParseAffixes(r io.Reader) (*Affixes, error) {
+	affixes := &Affixes{FlagType: "char", Classes: make(map[string]*AffixClass)}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var err error
+		switch fields[0] {
+		case "SET":
+			if len(fields) > 1 && !strings.EqualFold(fields[1], "UTF-8") {
+				err = fmt.Errorf("unsupported encoding %s", fields[1])
+			}
+		case "FLAG":
+			if len(fields) > 1 {
+				affixes.FlagType = fields[1]
+			}
+		case "NEEDAFFIX", "PSEUDOROOT":
+			affixes.NeedAffix = field(fields, 1)
+		case "ONLYINCOMPOUND":
+			affixes.OnlyInCompound = field(fields, 1)
+		case "FORBIDDENWORD":
+			affixes.Forbidden = field(fields, 1)
+		case "PFX", "SFX":
+			err = affixes.parseAffixLine(fields)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return affixes, nil
+}
+
+func field(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+// parseAffixLine handles both the header of an affix class, "SFX S Y 4", and its rules,
+// "SFX S y ies [^aeiou]y".
+func (a *Affixes) parseAffixLine(fields []string) error {
+	if len(fields) < 4 {
+		return fmt.Errorf("malformed %s line", fields[0])
+	}
+	flag := fields[1]
+	class, ok := a.Classes[flag]
+	if !ok {
+		if _, err := strconv.Atoi(fields[3]); err != nil {
+			return fmt.Errorf("malformed %s header for flag %s", fields[0], flag)
+		}
+		a.Classes[flag] = &AffixClass{Flag: flag, Prefix: fields[0] == "PFX", CrossProduct: fields[2] == "Y"}
+		return nil
+	}
+	if class.Prefix != (fields[0] == "PFX") {
+		return fmt.Errorf("flag %s is used for both prefixes and suffixes", flag)
+	}
+
+	rule := AffixRule{Strip: fields[2], Add: fields[3]}
+	if rule.Strip == "0" {
+		rule.Strip = ""
+	}
+	if add, flags, ok := strings.Cut(rule.Add, "/"); ok {
+		rule.Add = add
+		rule.Flags = a.SplitFlags(flags)
+	}
+	if rule.Add == "0" {
+		rule.Add = ""
+	}
+	condition, err := ParseCondition(field(fields, 4))
+	if err != nil {
+		return err
+	}
+	rule.Condition = condition
+	class.Rules = append(class.Rules, rule)
+	return nil
+}
+
+// SplitFlags splits a flag string from a dictionary or affix file into single flags, according
+// to the FLAG type of the affix file.
+func (a *Affixes) SplitFlags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var flags []string
+	switch a.FlagType {
+	case "long":
+		for i := 0; i < len(s); i += 2 {
+			flags = append(flags, s[i:min(i+2, len(s))])
+		}
+	case "num":
+		for _, flag := range strings.Split(s, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				flags = append(flags, flag)
+			}
+		}
+	default:
+		for _, r := range s {
+			flags = append(flags, string(r))
+		}
+	}
+	return flags
+}
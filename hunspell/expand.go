@@ -0,0 +1,219 @@
+package hunspell
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Entry is a stem from a dictionary file with the flags of the affix classes it takes.
+type Entry struct {
+	Stem  string
+	Flags []string
+}
+
+// has reports whether the entry carries flag.
+func (e Entry) has(flag string) bool {
+	return flag != "" && slices.Contains(e.Flags, flag)
+}
+
+// ParseDictionary reads a dictionary file: an optional word count followed by one stem per line,
+// each optionally followed by a slash and its flags. Morphological fields after the stem are
+// ignored.
+func ParseDictionary(r io.Reader, affixes *Affixes) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			first = false
+			if _, err := strconv.Atoi(line); err == nil {
+				continue
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		stem, flags := splitEntry(line)
+		entries = append(entries, Entry{Stem: stem, Flags: affixes.SplitFlags(flags)})
+	}
+	return entries, scanner.Err()
+}
+
+// splitEntry splits "stem/flags" at the first slash that is not escaped with a backslash.
+func splitEntry(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '/':
+			return strings.ReplaceAll(line[:i], `\/`, "/"), line[i+1:]
+		}
+	}
+	return strings.ReplaceAll(line, `\/`, "/"), ""
+}
+
+// ExpandOptions filter the words Expand produces.
+type ExpandOptions struct {
+	// MinLength and MaxLength bound the length of the words, in runes of their NFC form. Zero is
+	// no bound.
+	MinLength, MaxLength int
+	// SkipProperNouns drops the stems that start with a capital letter, along with every word
+	// derived from them.
+	SkipProperNouns bool
+	// ExcludeFlags are the flags of affix classes that are not applied, e.g. "S" for plurals in
+	// the English dictionary.
+	ExcludeFlags []string
+}
+
+// Form is a word of the expanded dictionary with the derivation that produced it.
+type Form struct {
+	Word string
+	Stem string
+	// Affixes are the flags of the affix classes applied to Stem to make Word, in the order they
+	// were applied. It is empty for stems that are words on their own.
+	Affixes []string
+}
+
+// Derived reports whether the word is derived with any of the affix classes named by flags.
+func (f Form) Derived(flags []string) bool {
+	return slices.ContainsFunc(f.Affixes, func(flag string) bool { return slices.Contains(flags, flag) })
+}
+
+// Expand returns every word the dictionary entries describe: each stem on its own and with each
+// of its affixes, including prefixes combined with suffixes where both classes allow it and
+// suffixes allowed by the continuation flags of another suffix. The words are NFC-normalized,
+// sorted and unique.
+func (a *Affixes) Expand(entries []Entry, opts ExpandOptions) []string {
+	forms := a.ExpandForms(entries, opts)
+	words := make([]string, len(forms))
+	for i, form := range forms {
+		words[i] = form.Word
+	}
+	return words
+}
+
+// ExpandForms is Expand that also returns how each word is derived. A word that several stems
+// or affix combinations produce is reported with the derivation using the fewest affixes, so a
+// word that is a stem of its own never counts as derived.
+func (a *Affixes) ExpandForms(entries []Entry, opts ExpandOptions) []Form {
+	e := expander{affixes: a, opts: opts, seen: make(map[string]int)}
+	for _, entry := range entries {
+		if opts.SkipProperNouns {
+			if r, _ := utf8.DecodeRuneInString(entry.Stem); unicode.IsUpper(r) {
+				continue
+			}
+		}
+		if entry.has(a.Forbidden) {
+			continue
+		}
+		e.expand(entry)
+	}
+	slices.SortFunc(e.forms, func(x, y Form) int { return strings.Compare(x.Word, y.Word) })
+	return e.forms
+}
+
+type expander struct {
+	affixes *Affixes
+	opts    ExpandOptions
+	// seen maps each word emitted so far to its index in forms.
+	seen  map[string]int
+	forms []Form
+}
+
+func (e *expander) emit(word, stem string, affixes ...string) {
+	word = norm.NFC.String(word)
+	length := utf8.RuneCountInString(word)
+	if (e.opts.MinLength > 0 && length < e.opts.MinLength) || (e.opts.MaxLength > 0 && length > e.opts.MaxLength) {
+		return
+	}
+	form := Form{Word: word, Stem: stem, Affixes: affixes}
+	if i, ok := e.seen[word]; ok {
+		if len(affixes) < len(e.forms[i].Affixes) {
+			e.forms[i] = form
+		}
+		return
+	}
+	e.seen[word] = len(e.forms)
+	e.forms = append(e.forms, form)
+}
+
+// classes returns the affix classes of the given kind named by flags, leaving out excluded ones.
+func (e *expander) classes(flags []string, prefix bool) []*AffixClass {
+	var classes []*AffixClass
+	for _, flag := range flags {
+		class, ok := e.affixes.Classes[flag]
+		if ok && class.Prefix == prefix && !slices.Contains(e.opts.ExcludeFlags, flag) {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+func (e *expander) expand(entry Entry) {
+	if !entry.has(e.affixes.NeedAffix) && !entry.has(e.affixes.OnlyInCompound) {
+		e.emit(entry.Stem, entry.Stem)
+	}
+	prefixes := e.classes(entry.Flags, true)
+	for _, prefix := range prefixes {
+		for _, rule := range prefix.Rules {
+			if word, ok := applyPrefix(rule, entry.Stem); ok {
+				e.emit(word, entry.Stem, prefix.Flag)
+			}
+		}
+	}
+
+	for _, suffix := range e.classes(entry.Flags, false) {
+		for _, rule := range suffix.Rules {
+			word, ok := applySuffix(rule, entry.Stem)
+			if !ok {
+				continue
+			}
+			e.emit(word, entry.Stem, suffix.Flag)
+			for _, next := range e.classes(rule.Flags, false) {
+				for _, nextRule := range next.Rules {
+					if twofold, ok := applySuffix(nextRule, word); ok {
+						e.emit(twofold, entry.Stem, suffix.Flag, next.Flag)
+					}
+				}
+			}
+			if !suffix.CrossProduct {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if !prefix.CrossProduct {
+					continue
+				}
+				for _, prefixRule := range prefix.Rules {
+					if both, ok := applyPrefix(prefixRule, word); ok {
+						e.emit(both, entry.Stem, suffix.Flag, prefix.Flag)
+					}
+				}
+			}
+		}
+	}
+}
+
+func applyPrefix(rule AffixRule, stem string) (string, bool) {
+	if !strings.HasPrefix(stem, rule.Strip) || !rule.Condition.matchesStart([]rune(stem)) {
+		return "", false
+	}
+	return rule.Add + stem[len(rule.Strip):], true
+}
+
+func applySuffix(rule AffixRule, stem string) (string, bool) {
+	if !strings.HasSuffix(stem, rule.Strip) || !rule.Condition.matchesEnd([]rune(stem)) {
+		return "", false
+	}
+	return stem[:len(stem)-len(rule.Strip)] + rule.Add, true
+}
@@ -0,0 +1,171 @@
+package hunspell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testAffixes is a small affix file with a suffix class that depends on the end of the stem,
+// prefixes with and without cross products and a suffix whose words take a further suffix.
+const testAffixes = `SET UTF-8
+NEEDAFFIX X
+
+PFX U Y 1
+PFX U 0 un .
+
+PFX R N 1
+PFX R 0 re .
+
+SFX S Y 3
+SFX S y ies [^aeiou]y
+SFX S 0 s [aeiou]y
+SFX S 0 s [^y]
+
+SFX D Y 2
+SFX D 0 ed [^e]
+SFX D 0 d e
+
+SFX B N 1
+SFX B 0 able/S .
+`
+
+func parseTest(t *testing.T, dic string) (*Affixes, []Entry) {
+	t.Helper()
+	affixes, err := ParseAffixes(strings.NewReader(testAffixes))
+	if err != nil {
+		t.Fatalf("ParseAffixes: %v", err)
+	}
+	entries, err := ParseDictionary(strings.NewReader(dic), affixes)
+	if err != nil {
+		t.Fatalf("ParseDictionary: %v", err)
+	}
+	return affixes, entries
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name string
+		dic  string
+		opts ExpandOptions
+		want []string
+	}{
+		{
+			name: "suffix conditions",
+			dic:  "3\nfly/S\nboy/S\ncat/S\n",
+			want: []string{"boy", "boys", "cat", "cats", "flies", "fly"},
+		},
+		{
+			name: "strip and condition",
+			dic:  "bake/D\nlock/D\n",
+			want: []string{"bake", "baked", "lock", "locked"},
+		},
+		{
+			name: "prefix",
+			dic:  "do/U\n",
+			want: []string{"do", "undo"},
+		},
+		{
+			name: "cross product",
+			dic:  "lock/UD\n",
+			want: []string{"lock", "locked", "unlock", "unlocked"},
+		},
+		{
+			name: "prefix without cross product",
+			dic:  "lock/RD\n",
+			want: []string{"lock", "locked", "relock"},
+		},
+		{
+			name: "continuation flags",
+			dic:  "read/B\n",
+			want: []string{"read", "readable", "readables"},
+		},
+		{
+			name: "need affix",
+			dic:  "walk/XD\n",
+			want: []string{"walked"},
+		},
+		{
+			name: "excluded flags",
+			dic:  "lock/UDS\n",
+			opts: ExpandOptions{ExcludeFlags: []string{"S", "U"}},
+			want: []string{"lock", "locked"},
+		},
+		{
+			name: "proper nouns",
+			dic:  "Paris\ncat/S\n",
+			opts: ExpandOptions{SkipProperNouns: true},
+			want: []string{"cat", "cats"},
+		},
+		{
+			name: "lengths",
+			dic:  "cat/S\nfly/S\n",
+			opts: ExpandOptions{MinLength: 4, MaxLength: 4},
+			want: []string{"cats"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			affixes, entries := parseTest(t, tt.dic)
+			if got := affixes.Expand(entries, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandForms(t *testing.T) {
+	affixes, entries := parseTest(t, "lock/UD\nread/B\nreads\n")
+	forms := make(map[string]Form)
+	for _, form := range affixes.ExpandForms(entries, ExpandOptions{}) {
+		forms[form.Word] = form
+	}
+	want := map[string]Form{
+		"lock":      {Word: "lock", Stem: "lock"},
+		"locked":    {Word: "locked", Stem: "lock", Affixes: []string{"D"}},
+		"unlock":    {Word: "unlock", Stem: "lock", Affixes: []string{"U"}},
+		"unlocked":  {Word: "unlocked", Stem: "lock", Affixes: []string{"D", "U"}},
+		"read":      {Word: "read", Stem: "read"},
+		"readable":  {Word: "readable", Stem: "read", Affixes: []string{"B"}},
+		"readables": {Word: "readables", Stem: "read", Affixes: []string{"B", "S"}},
+		"reads":     {Word: "reads", Stem: "reads"},
+	}
+	if !reflect.DeepEqual(forms, want) {
+		t.Errorf("ExpandForms() = %v, want %v", forms, want)
+	}
+	if !forms["readables"].Derived([]string{"S"}) || forms["readable"].Derived([]string{"S"}) {
+		t.Error("Derived does not follow the affixes of the form")
+	}
+}
+
+func TestSplitFlags(t *testing.T) {
+	tests := []struct {
+		flagType string
+		s        string
+		want     []string
+	}{
+		{"char", "ABc", []string{"A", "B", "c"}},
+		{"long", "AaBb", []string{"Aa", "Bb"}},
+		{"num", "1, 23,4", []string{"1", "23", "4"}},
+		{"UTF-8", "äö", []string{"ä", "ö"}},
+		{"char", "", nil},
+	}
+	for _, tt := range tests {
+		affixes := &Affixes{FlagType: tt.flagType}
+		if got := affixes.SplitFlags(tt.s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s SplitFlags(%q) = %q, want %q", tt.flagType, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseDictionaryEscapes(t *testing.T) {
+	affixes := &Affixes{FlagType: "char"}
+	entries, err := ParseDictionary(strings.NewReader("2\nand\\/or/S\nword/AB po:noun\n"), affixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{Stem: "and/or", Flags: []string{"S"}}, {Stem: "word", Flags: []string{"A", "B"}}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParseDictionary() = %v, want %v", entries, want)
+	}
+}